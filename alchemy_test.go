@@ -0,0 +1,195 @@
+package alchemyapi_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+var record = flag.Bool("record", false, "record the responses of TestAlchemy into "+cassette+", from the live service with apikey.txt, from alchemytest.Server otherwise")
+
+// cassette is the file TestAlchemy records responses into and replays them from.
+const cassette = "testdata/TestAlchemy.json"
+
+// newTestClient returns a client for TestAlchemy. With -record it records the
+// responses of the live service into the cassette if apikey.txt is present, and
+// those of a local alchemytest.Server otherwise. Without -record it replays the
+// cassette if there is one, calls the live service if apikey.txt is present,
+// and uses a local alchemytest.Server as a last resort.
+// The committed cassette was recorded from alchemytest.Server, so TestAlchemy
+// runs offline; record it again with apikey.txt to check the live service.
+func newTestClient(t *testing.T) *alchemyapi.Client {
+	b, keyErr := ioutil.ReadFile("apikey.txt")
+	if *record {
+		key, baseUrl, transport := string(b), liveURL, http.DefaultTransport
+		if keyErr != nil {
+			server := alchemytest.NewServer()
+			t.Cleanup(server.Close)
+			// Recorded with the paths of the live service, so that the cassette is replayed the same way.
+			key, baseUrl = alchemytest.APIKey, server.URL+"/calls"
+			transport = stripPrefix{prefix: "/calls", transport: server.Client().Transport}
+		}
+		recorder, err := alchemytest.NewRecorder(cassette, alchemytest.ModeRecord, transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if err := recorder.Save(); err != nil {
+				t.Error(err)
+			}
+		})
+		return alchemyapi.New(key, baseUrl, recorder.Client())
+	}
+	if _, err := os.Stat(cassette); err == nil {
+		replayer, err := alchemytest.NewRecorder(cassette, alchemytest.ModeReplay, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return alchemyapi.New("", liveURL, replayer.Client())
+	}
+	if keyErr == nil {
+		return alchemyapi.New(string(b), liveURL, &http.Client{})
+	}
+	server := alchemytest.NewServer()
+	t.Cleanup(server.Close)
+	return alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+}
+
+// stripPrefix is a RoundTripper removing prefix from the path of the requests.
+type stripPrefix struct {
+	prefix    string
+	transport http.RoundTripper
+}
+
+func (p stripPrefix) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Path = strings.TrimPrefix(req.URL.Path, p.prefix)
+	return p.transport.RoundTrip(req)
+}
+
+// liveURL is the base URL of the live service.
+const liveURL = "http://access.alchemyapi.com/calls"
+
+func TestAlchemy(t *testing.T) {
+	a := newTestClient(t)
+	assert := alchemyapi.NewAssert(t)
+
+	testText := "Bob broke my heart, and then made up this silly sentence to test the Ruby SDK"
+	testHtml := "<html><head><title>The best SDK Test | AlchemyAPI</title></head><body><h1>Hello World!</h1><p>My favorite language is Ruby</p></body></html>"
+	testUrl := "http://www.nytimes.com/2013/07/13/us/politics/a-day-of-friction-notable-even-for-a-fractious-congress.html?_r=0"
+
+	response, err := a.Entities("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Entities("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Entities("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Entities("random", testText)
+	assert.NotNil(err)
+	response, err = a.Keywords("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Keywords("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Keywords("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Keywords("random", testText)
+	assert.NotNil(err)
+	response, err = a.Concepts("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Concepts("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Concepts("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Concepts("random", testText)
+	assert.NotNil(err)
+	response, err = a.Sentiment("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Sentiment("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Sentiment("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Sentiment("random", testText)
+	assert.NotNil(err)
+	response, err = a.SentimentTargeted("text", testText, "heart")
+	assert.Equal(response["status"], "OK")
+	response, err = a.SentimentTargeted("html", testHtml, "language")
+	assert.Equal(response["status"], "OK")
+	response, err = a.SentimentTargeted("url", testUrl, "Congress")
+	assert.Equal(response["status"], "OK")
+	response, err = a.SentimentTargeted("text", testText, "")
+	assert.NotNil(err)
+	response, err = a.SentimentTargeted("random", testUrl, "Congress")
+	assert.NotNil(err)
+	response, err = a.Text("text", testText)
+	assert.NotNil(err)
+	response, err = a.Text("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Text("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.TextRaw("text", testText)
+	assert.NotNil(err)
+	response, err = a.TextRaw("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.TextRaw("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Author("text", testText)
+	assert.NotNil(err)
+	response, err = a.Author("html", testHtml)
+	assert.NotNil(err)
+	response, err = a.Author("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Title("text", testText)
+	assert.NotNil(err)
+	response, err = a.Title("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Title("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Relations("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Relations("html", testHtml)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Relations("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Relations("random", testText)
+	assert.NotNil(err)
+	response, err = a.Category("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Category("html", testHtml, map[string][]string{"url": []string{"test"}})
+	assert.Equal(response["status"], "OK")
+	response, err = a.Category("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Category("random", testText)
+	assert.NotNil(err)
+	response, err = a.Feeds("text", testText)
+	assert.NotNil(err)
+	response, err = a.Feeds("html", testHtml, map[string][]string{"url": []string{"test"}})
+	assert.Equal(response["status"], "OK")
+	response, err = a.Feeds("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Microformats("text", testText)
+	assert.NotNil(err)
+	response, err = a.Microformats("html", testHtml, map[string][]string{"url": []string{"test"}})
+	assert.Equal(response["status"], "OK")
+	response, err = a.Microformats("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Taxonomy("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Taxonomy("url", testUrl)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Taxonomy("html", testHtml, map[string][]string{"url": []string{"test"}})
+	assert.Equal(response["status"], "OK")
+	response, err = a.Taxonomy("random", testText)
+	assert.NotNil(err)
+	response, err = a.Combined("html", testHtml, map[string][]string{"url": []string{"test"}})
+	assert.NotNil(err)
+	response, err = a.Combined("text", testText)
+	assert.Equal(response["status"], "OK")
+	response, err = a.Combined("url", testUrl)
+	assert.Equal(response["status"], "OK")
+}
@@ -1,347 +1,267 @@
 package alchemyapi
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
-	"path"
-	"runtime"
+	"strings"
 )
 
 type (
-	AlchemyAPI struct {
-		Endpoints map[string]map[string]string
-	}
-	alchemy struct {
+	// Client is an AlchemyAPI client. Create one with NewClient or New.
+	// A Client is safe for concurrent use by multiple goroutines once it is
+	// configured. Calls never modify the url.Values passed to them.
+	Client struct {
 		api        *AlchemyAPI
 		key        string
 		baseUrl    string
 		httpClient *http.Client
-	}
-	result map[string]interface{}
-)
-
-var api AlchemyAPI
-
-func init() {
-	_, currentFilename, _, _ := runtime.Caller(0)
-	file, err := ioutil.ReadFile(path.Join(path.Dir(currentFilename), "endpoints.json"))
-	if err != nil {
-		panic(err)
+		retry      *RetryPolicy
+		userAgent  string
+		logger     Logger
+		// maxResponseSize is the largest response body accepted, DefaultMaxResponseSize if 0.
+		maxResponseSize int64
+		// dryRun makes calls return a *DryRunError instead of being sent.
+		dryRun bool
+		budget *Budget
+		// limiter and endpointLimiters are the rate limits of the client and of its actions.
+		limiter          *limiter
+		endpointLimiters map[string]*limiter
 	}
 
-	err = json.Unmarshal(file, &api.Endpoints)
-	if err != nil {
-		panic(err)
-	}
-}
+	// Result is an AlchemyAPI response decoded as a JSON object.
+	Result map[string]interface{}
 
-func New(key string, baseUrl string, httpClient *http.Client) *alchemy {
-	return &alchemy{api: &api, key: key, baseUrl: baseUrl, httpClient: httpClient}
-}
-func (a *alchemy) analyze(action string, flavor string, data string, options ...url.Values) (result, error) {
-	var opts url.Values
-	if len(options) != 0 {
-		opts = options[0]
-	} else {
-		opts = url.Values{}
-	}
-	if _, ok := api.Endpoints[action][flavor]; !ok {
-		return nil, fmt.Errorf("%s analysis for %s not available", action, flavor)
+	// call describes a single request to an AlchemyAPI endpoint.
+	call struct {
+		action  string
+		flavor  string
+		path    string
+		options url.Values
+		body    io.Reader
+		// transactions is the totalTransactions of the last response, 0 if it had none.
+		transactions int
 	}
-	opts[flavor] = []string{data}
-	return a.Analyze(a.api.Endpoints[action][flavor], opts)
-}
-
-func (a *alchemy) Analyze(ep string, options url.Values) (result, error) {
-	var (
-		v result
-	)
-	targetUrl := a.baseUrl + ep
-	options["apikey"] = []string{a.key}
-	options["outputMode"] = []string{"json"}
-	response, err := a.httpClient.PostForm(targetUrl, options)
-	if err != nil {
-		return nil, err
-	}
-	body, _ := ioutil.ReadAll(response.Body)
-	if response != nil {
-		response.Body.Close()
-	}
-	err = json.Unmarshal(body, &v)
-	if v["status"] == "ERROR" {
-		err = fmt.Errorf("%v", v["statusInfo"])
-	}
-	return v, err
-}
+)
 
-// Calculates the sentiment for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/sentiment-analysis/
-// For the docs, please refer to: http://www.alchemyapi.com/api/sentiment-analysis/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// showSourceText -> 0: disabled (default), 1: enabled
-// It returns the response as an interface
-func (a *alchemy) Sentiment(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("sentiment", flavor, data, options...)
-}
+// DefaultMaxResponseSize is the largest response body a client accepts unless set otherwise.
+const DefaultMaxResponseSize = 10 << 20
 
-// Calculates the targeted sentiment for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/sentiment-analysis/
-// For the docs, please refer to: http://www.alchemyapi.com/api/sentiment-analysis/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// target -> the word or phrase to run sentiment analysis on.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// showSourceText	-> 0: disabled, 1: enabled
-// It returns the response as an interface
-func (a *alchemy) SentimentTargeted(flavor string, data string, target string, options ...url.Values) (result, error) {
-	var opts url.Values
-	if len(options) != 0 {
-		opts = options[0]
-	} else {
-		opts = url.Values{}
+// New returns a client for the given API key. Every client gets its own copy
+// of the default endpoint table, which can be changed through Endpoints.
+// An empty baseUrl means DefaultBaseURL and a nil httpClient a client with
+// the default timeouts. NewClient offers more options.
+func New(key string, baseUrl string, httpClient *http.Client) *Client {
+	if baseUrl == "" {
+		baseUrl = DefaultBaseURL
 	}
-	if target == "" {
-		return nil, errors.New("targeted sentiment requires a non-null target")
+	if httpClient == nil {
+		httpClient = defaultHTTPClient()
 	}
-	opts["target"] = []string{target}
-	return a.analyze("sentiment_targeted", flavor, data, opts)
+	return &Client{api: api.Clone(), key: key, baseUrl: baseUrl, httpClient: httpClient}
 }
 
-// Extracts the entities for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/entity-extraction/
-// For the docs, please refer to: http://www.alchemyapi.com/api/entity-extraction/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// disambiguate -> disambiguate entities (i.e. Apple the company vs. apple the fruit). 0: disabled, 1: enabled (default)
-// linkedData -> include linked data on disambiguated entities. 0: disabled, 1: enabled (default)
-// coreference -> resolve coreferences (i.e. the pronouns that correspond to named entities). 0: disabled, 1: enabled (default)
-// quotations -> extract quotations by entities. 0: disabled (default), 1: enabled.
-// sentiment -> analyze sentiment for each entity. 0: disabled (default), 1: enabled. Requires 1 additional API transction if enabled.
-// showSourceText -> 0: disabled (default), 1: enabled
-// maxRetrieve -> the maximum number of entities to retrieve (default: 50)
-// It returns the response as an interface
-func (a *alchemy) Entities(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("entities", flavor, data, options...)
+// NewWithEndpoints is like New but reads an endpoint table from endpoints and
+// applies it over the default table: its paths are added to the default ones,
+// replacing those of the same action and flavor.
+func NewWithEndpoints(key string, baseUrl string, httpClient *http.Client, endpoints io.Reader) (*Client, error) {
+	return NewClient(key, WithBaseURL(baseUrl), WithHTTPClient(httpClient), WithEndpoints(endpoints))
 }
 
-// Extracts the author from a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/author-extraction/
-// For the docs, please refer to: http://www.alchemyapi.com/api/author-extraction/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// none
-// It returns the response as an interface
-func (a *alchemy) Author(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("author", flavor, data, options...)
+// SetMaxResponseSize sets the largest response body, in bytes, the client accepts.
+// Larger responses fail with a *ResponseError. It must be called before the client is used.
+func (a *Client) SetMaxResponseSize(n int64) {
+	a.maxResponseSize = n
 }
 
-// Extracts the keywords from text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/keyword-extraction/
-// For the docs, please refer to: http://www.alchemyapi.com/api/keyword-extraction/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// keywordExtractMode -> normal (default), strict
-// sentiment -> analyze sentiment for each keyword. 0: disabled (default), 1: enabled. Requires 1 additional API transaction if enabled.
-// showSourceText -> 0: disabled (default), 1: enabled.
-// maxRetrieve -> the max number of keywords returned (default: 50)
-// It returns the response as an interface
-func (a *alchemy) Keywords(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("keywords", flavor, data, options...)
-}
-
-// Tags the concepts for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/concept-tagging/
-// For the docs, please refer to: http://www.alchemyapi.com/api/concept-tagging/
-// Available Options:
-// maxRetrieve -> the maximum number of concepts to retrieve (default: 8)
-// linkedData -> include linked data, 0: disabled, 1: enabled (default)
-// showSourceText -> 0:disabled (default), 1: enabled
-// It returns the response as an interface
-
-func (a *alchemy) Concepts(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("concepts", flavor, data, options...)
-}
-
-// Categorizes the text for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/text-categorization/
-// For the docs, please refer to: http://www.alchemyapi.com/api/text-categorization/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// showSourceText -> 0: disabled (default), 1: enabled
-// It returns the response as an interface
-
-func (a *alchemy) Category(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("category", flavor, data, options...)
-}
-
-// Extracts the relations for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/relation-extraction/
-// For the docs, please refer to: http://www.alchemyapi.com/api/relation-extraction/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// sentiment -> 0: disabled (default), 1: enabled. Requires one additional API transaction if enabled.
-// keywords -> extract keywords from the subject and object. 0: disabled (default), 1: enabled. Requires one additional API transaction if enabled.
-// entities -> extract entities from the subject and object. 0: disabled (default), 1: enabled. Requires one additional API transaction if enabled.
-// requireEntities -> only extract relations that have entities. 0: disabled (default), 1: enabled.
-// sentimentExcludeEntities -> exclude full entity name in sentiment analysis. 0: disabled, 1: enabled (default)
-// disambiguate -> disambiguate entities (i.e. Apple the company vs. apple the fruit). 0: disabled, 1: enabled (default)
-// linkedData -> include linked data with disambiguated entities. 0: disabled, 1: enabled (default).
-// coreference -> resolve entity coreferences. 0: disabled, 1: enabled (default)
-// showSourceText -> 0: disabled (default), 1: enabled.
-// maxRetrieve -> the maximum number of relations to extract (default: 50, max: 100)
-// It returns the response as an interface
-
-func (a *alchemy) Relations(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("relations", flavor, data, options...)
+// Endpoints returns the endpoint table of the client. Changes made to it
+// through its methods apply to the following calls of the client only.
+func (a *Client) Endpoints() *AlchemyAPI {
+	return a.api
 }
 
-// Detects the language for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/api/language-detection/
-// For the docs, please refer to: http://www.alchemyapi.com/products/features/language-detection/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// none
-// It returns the response as an interface
-
-func (a *alchemy) Language(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("language", flavor, data, options...)
-}
-
-// Extracts the cleaned text (removes ads, navigation, etc.) for text, a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/text-extraction/
-// For the docs, please refer to: http://www.alchemyapi.com/api/text-extraction/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// useMetadata -> utilize meta description data, 0: disabled, 1: enabled (default)
-// extractLinks -> include links, 0: disabled (default), 1: enabled.
-// It returns the response as an interface
-func (a *alchemy) Text(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("text", flavor, data, options...)
+func (a *Client) analyze(ctx context.Context, action string, flavor string, data string, options ...url.Values) (Result, error) {
+	var v Result
+	err := a.analyzeInto(ctx, &v, action, flavor, data, options...)
+	return v, err
 }
 
-// Extracts the raw text (includes ads, navigation, etc.) for a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/text-extraction/
-// For the docs, please refer to: http://www.alchemyapi.com/api/text-extraction/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// none
-// It returns the response as an interface
-
-func (a *alchemy) TextRaw(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("text_raw", flavor, data, options...)
+// analyzeInto runs the action for flavor and decodes the response into v.
+func (a *Client) analyzeInto(ctx context.Context, v interface{}, action string, flavor string, data string, options ...url.Values) error {
+	opts := optionsOf(options...)
+	if err := a.api.validate(action, flavor, len(data), opts); err != nil {
+		return err
+	}
+	if flavor == "image" {
+		return a.analyzeImage(ctx, v, action, strings.NewReader(data), opts)
+	}
+	path, ok := a.api.Path(action, flavor)
+	if !ok {
+		if apiErr != nil {
+			return apiErr
+		}
+		return fmt.Errorf("%s analysis for %s not available", action, flavor)
+	}
+	opts[flavor] = []string{data}
+	return a.post(ctx, &call{action: action, flavor: flavor, path: path, options: opts}, v)
 }
 
-// Extracts the title for a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/text-extraction/
-// For the docs, please refer to: http://www.alchemyapi.com/api/text-extraction/
-// INPUT:
-// flavor -> which version of the call, i.e. text, url or html.
-// data -> the data to analyze, either the text, the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// useMetadata -> utilize title info embedded in meta data, 0: disabled, 1: enabled (default)
-// It returns the response as an interface
-func (a *alchemy) Title(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("title", flavor, data, options...)
+// analyzeImage posts the raw image bytes read from image to the image flavor of the action
+// and decodes the response into v.
+func (a *Client) analyzeImage(ctx context.Context, v interface{}, action string, image io.Reader, options ...url.Values) error {
+	opts := optionsOf(options...)
+	if err := a.api.validate(action, "image", -1, opts); err != nil {
+		return err
+	}
+	// The size of the image is only known once read, up to one byte over the limit.
+	if max, ok := a.api.maxInputSize(action, "image"); ok && image != nil {
+		b, err := ioutil.ReadAll(io.LimitReader(image, int64(max)+1))
+		if err != nil {
+			return err
+		}
+		if len(b) > max {
+			return fmt.Errorf("%s: image exceeds the limit of %d bytes: %w", action, max, ErrContentExceedsSizeLimit)
+		}
+		image = bytes.NewReader(b)
+	}
+	path, ok := a.api.Path(action, "image")
+	if !ok {
+		if apiErr != nil {
+			return apiErr
+		}
+		return fmt.Errorf("%s analysis for image not available", action)
+	}
+	opts["imagePostMode"] = []string{"raw"}
+	return a.post(ctx, &call{action: action, flavor: "image", path: path, options: opts, body: image}, v)
 }
 
-// Parses the microformats for a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/microformats-parsing/
-// For the docs, please refer to: http://www.alchemyapi.com/api/microformats-parsing/
-// INPUT:
-// flavor -> which version of the call, i.e.  url or html.
-// data -> the data to analyze, either the the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// none
-// It returns the response as an interface
-
-func (a *alchemy) Microformats(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("microformats", flavor, data, options...)
+// optionsOf returns a copy of the options passed to an endpoint method, or empty
+// options if none were passed. Calls only modify the copy, never the caller's values.
+func optionsOf(options ...url.Values) url.Values {
+	opts := url.Values{}
+	if len(options) != 0 {
+		for name, values := range options[0] {
+			opts[name] = append([]string(nil), values...)
+		}
+	}
+	return opts
 }
 
-// Detects the RSS/ATOM feeds for a URL or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/feed-detection/
-// For the docs, please refer to: http://www.alchemyapi.com/api/feed-detection/
-
-// INPUT:
-// flavor -> which version of the call, i.e.  url or html.
-// data -> the data to analyze, either the the url or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-
-// Available Options:
-// none
-// It returns the response as an interface
-func (a *alchemy) Feeds(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("feeds", flavor, data, options...)
+// Analyze posts options to the endpoint path ep, e.g. "/text/TextGetLanguage", and returns the response.
+func (a *Client) Analyze(ep string, options url.Values) (Result, error) {
+	return a.AnalyzeContext(context.Background(), ep, options)
 }
 
-// Categorizes the text for a URL, text or HTML.
-// For an overview, please refer to: http://www.alchemyapi.com/products/features/text-categorization/
-// For the docs, please refer to: http://www.alchemyapi.com/api/taxonomy/
-// INPUT:
-// flavor -> which version of the call, i.e.  url, text or html.
-// data -> the data to analyze, either the the url, text or html code.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// showSourceText -> 0: disabled (default), 1: enabled.
-// It returns the response as an interface
-func (a *alchemy) Taxonomy(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("taxonomy", flavor, data, options...)
+// AnalyzeContext is like Analyze but uses ctx for the request.
+// If ctx is canceled or its deadline passes before the response is read,
+// the error returned is ctx.Err(), i.e. context.Canceled or context.DeadlineExceeded.
+func (a *Client) AnalyzeContext(ctx context.Context, ep string, options url.Values) (Result, error) {
+	var v Result
+	c := &call{path: ep, options: optionsOf(options)}
+	c.action, c.flavor = a.api.endpointOf(ep)
+	err := a.post(ctx, c, &v)
+	return v, err
 }
 
-// Combined call (see options below for available extractions) for a URL or text.
-// INPUT:
-// flavor -> which version of the call, i.e.  url or text.
-// data -> the data to analyze, either the the url or text.
-// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.
-// Available Options:
-// extract -> VALUE,VALUE,VALUE,... (possible VALUEs: page-image,entity,keyword,title,author,taxonomy,concept,relation,doc-sentiment)
-// extractMode -> (only applies when 'page-image' VALUE passed to 'extract' option)
-// 		trust-metadata: less CPU-intensive, less accurate
-// 		always-infer: more CPU-intensive, more accurate
-// disambiguate -> whether to disambiguate detected entities, 0: disabled, 1: enabled (default)
-// linkedData -> whether to include Linked Data content links with disambiguated entities, 0: disabled, 1: enabled (default). disambiguate must be enabled to use this.
-// coreference -> whether to he/she/etc coreferences into detected entities, 0: disabled, 1: enabled (default)
-// quotations -> whether to enable quotations extraction, 0: disabled (default), 1: enabled
-// sentiment -> whether to enable entity-level sentiment analysis, 0: disabled (default), 1: enabled. Requires one additional API transaction if enabled.
-// showSourceText -> 0: disabled (default), 1: enabled.
-// maxRetrieve -> maximum number of named entities to extract (default: 50)
-// It returns the response as an interface
-func (a *alchemy) Combined(flavor string, data string, options ...url.Values) (result, error) {
-	return a.analyze("combined", flavor, data, options...)
+// send makes a single attempt of the call and decodes the JSON response into v.
+// If the call has no body the options are form encoded, otherwise they are
+// sent in the query string and the body is posted as is.
+func (a *Client) send(ctx context.Context, c *call, v interface{}) error {
+	var (
+		status  Response
+		request *http.Request
+		err     error
+	)
+	c.transactions = 0
+	targetUrl := a.baseUrl + c.path
+	options := c.options
+	options["apikey"] = []string{a.key}
+	options["outputMode"] = []string{"json"}
+	if c.body == nil {
+		request, err = http.NewRequestWithContext(ctx, "POST", targetUrl, strings.NewReader(options.Encode()))
+		if err == nil {
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		request, err = http.NewRequestWithContext(ctx, "POST", targetUrl+"?"+options.Encode(), c.body)
+		if err == nil {
+			request.Header.Set("Content-Type", "application/octet-stream")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if a.userAgent != "" {
+		request.Header.Set("User-Agent", a.userAgent)
+	}
+	release, err := a.waitLimits(ctx, c.action)
+	if err != nil {
+		return err
+	}
+	response, err := a.httpClient.Do(request)
+	if err != nil {
+		release()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// The URL of raw image requests carries the API key, which must not end up in errors and logs.
+		if urlErr, ok := err.(*url.Error); ok && c.body != nil {
+			query := url.Values{}
+			for name, values := range options {
+				if name != "apikey" {
+					query[name] = values
+				}
+			}
+			urlErr.URL = targetUrl + "?" + query.Encode()
+		}
+		return err
+	}
+	maxSize := a.maxResponseSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxResponseSize
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(response.Body, maxSize+1))
+	response.Body.Close()
+	release()
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// Reported like the transport errors of the http.Client, as the response was cut short.
+		return &url.Error{Op: "Post", URL: targetUrl, Err: err}
+	}
+	respErr := &ResponseError{
+		Path:        c.path,
+		StatusCode:  response.StatusCode,
+		Status:      response.Status,
+		ContentType: response.Header.Get("Content-Type"),
+		Snippet:     snippet(content),
+	}
+	mediaType, _, _ := mime.ParseMediaType(respErr.ContentType)
+	isJSON := json.Unmarshal(content, &status) == nil
+	switch {
+	case int64(len(content)) > maxSize:
+		respErr.Reason = fmt.Sprintf("response larger than %d bytes", maxSize)
+	case mediaType == "text/html" || strings.HasSuffix(mediaType, "xml"):
+		respErr.Reason = "unexpected content type"
+	case (response.StatusCode < 200 || response.StatusCode > 299) && !(isJSON && status.Status == "ERROR"):
+		respErr.Reason = "unexpected status"
+	case !isJSON:
+		respErr.Reason = "invalid JSON response"
+	}
+	if respErr.Reason != "" {
+		return respErr
+	}
+	c.transactions = int(status.TotalTransactions)
+	err = json.Unmarshal(content, v)
+	if status.Status == "ERROR" {
+		err = newAPIError(c, response.StatusCode, status.StatusInfo)
+	}
+	return err
 }
@@ -1,8 +1,6 @@
 package alchemyapi
 
 import (
-	"io/ioutil"
-	"net/http"
 	"reflect"
 	"runtime"
 	"testing"
@@ -82,123 +80,3 @@ func normalizeValue(value interface{}) interface{} {
 	}
 	return value
 }
-
-func TestAlchemy(t *testing.T) {
-	b, _ := ioutil.ReadFile("apikey.txt")
-	key := string(b)
-	a := New(key, "http://access.alchemyapi.com/calls", &http.Client{})
-	assert := NewAssert(t)
-
-	testText := "Bob broke my heart, and then made up this silly sentence to test the Ruby SDK"
-	testHtml := "<html><head><title>The best SDK Test | AlchemyAPI</title></head><body><h1>Hello World!</h1><p>My favorite language is Ruby</p></body></html>"
-	testUrl := "http://www.nytimes.com/2013/07/13/us/politics/a-day-of-friction-notable-even-for-a-fractious-congress.html?_r=0"
-
-	response, err := a.Entities("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Entities("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Entities("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Entities("random", testText)
-	assert.NotNil(err)
-	response, err = a.Keywords("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Keywords("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Keywords("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Keywords("random", testText)
-	assert.NotNil(err)
-	response, err = a.Concepts("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Concepts("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Concepts("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Concepts("random", testText)
-	assert.NotNil(err)
-	response, err = a.Sentiment("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Sentiment("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Sentiment("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Sentiment("random", testText)
-	assert.NotNil(err)
-	response, err = a.SentimentTargeted("text", testText, "heart")
-	assert.Equal(response["status"], "OK")
-	response, err = a.SentimentTargeted("html", testHtml, "language")
-	assert.Equal(response["status"], "OK")
-	response, err = a.SentimentTargeted("url", testUrl, "Congress")
-	assert.Equal(response["status"], "OK")
-	response, err = a.SentimentTargeted("text", testText, "")
-	assert.NotNil(err)
-	response, err = a.SentimentTargeted("random", testUrl, "Congress")
-	assert.NotNil(err)
-	response, err = a.Text("text", testText)
-	assert.NotNil(err)
-	response, err = a.Text("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Text("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.TextRaw("text", testText)
-	assert.NotNil(err)
-	response, err = a.TextRaw("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.TextRaw("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Author("text", testText)
-	assert.NotNil(err)
-	response, err = a.Author("html", testHtml)
-	assert.NotNil(err)
-	response, err = a.Author("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Title("text", testText)
-	assert.NotNil(err)
-	response, err = a.Title("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Title("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Relations("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Relations("html", testHtml)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Relations("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Relations("random", testText)
-	assert.NotNil(err)
-	response, err = a.Category("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Category("html", testHtml, map[string][]string{"url": []string{"test"}})
-	assert.Equal(response["status"], "OK")
-	response, err = a.Category("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Category("random", testText)
-	assert.NotNil(err)
-	response, err = a.Feeds("text", testText)
-	assert.NotNil(err)
-	response, err = a.Feeds("html", testHtml, map[string][]string{"url": []string{"test"}})
-	assert.Equal(response["status"], "OK")
-	response, err = a.Feeds("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Microformats("text", testText)
-	assert.NotNil(err)
-	response, err = a.Microformats("html", testHtml, map[string][]string{"url": []string{"test"}})
-	assert.Equal(response["status"], "OK")
-	response, err = a.Microformats("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Taxonomy("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Taxonomy("url", testUrl)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Taxonomy("html", testHtml, map[string][]string{"url": []string{"test"}})
-	assert.Equal(response["status"], "OK")
-	response, err = a.Taxonomy("random", testText)
-	assert.NotNil(err)
-	response, err = a.Combined("html", testHtml, map[string][]string{"url": []string{"test"}})
-	assert.NotNil(err)
-	response, err = a.Combined("text", testText)
-	assert.Equal(response["status"], "OK")
-	response, err = a.Combined("url", testUrl)
-	assert.Equal(response["status"], "OK")
-}
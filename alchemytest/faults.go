@@ -0,0 +1,159 @@
+package alchemytest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+)
+
+// Kinds of faults a Server can inject.
+const (
+	// FaultLatency only delays the response by the fault's Latency.
+	FaultLatency FaultKind = iota
+	// FaultDrop closes the connection without answering.
+	FaultDrop
+	// FaultStatus answers with the fault's StatusCode (default 503) and a plain text body.
+	FaultStatus
+	// FaultTruncate sends the first half of the normal response and closes the connection.
+	FaultTruncate
+	// FaultNonJSON answers 200 OK with a body that is not JSON.
+	FaultNonJSON
+	// FaultHTML answers with the HTML error page of a gateway and the fault's StatusCode (default 502).
+	FaultHTML
+	// FaultAPIError answers with an ERROR status and the fault's StatusInfo
+	// (default daily-transaction-limit-exceeded).
+	FaultAPIError
+)
+
+type (
+	// FaultKind is a kind of misbehavior injected by a Server.
+	FaultKind int
+
+	// Fault describes a misbehavior of the Server, injected with InjectFault.
+	Fault struct {
+		Kind FaultKind
+		// Every makes the fault apply to every Nth matching request only; 0 and 1 mean every request.
+		Every int
+		// Action restricts the fault to the requests of an action, e.g. "entities"; "" matches all.
+		Action string
+		// Latency delays the response. It applies to faults of any kind.
+		Latency    time.Duration
+		StatusCode int
+		StatusInfo string
+	}
+
+	// fault is an injected Fault and the count of the requests it matched.
+	fault struct {
+		Fault
+		matched int
+	}
+)
+
+// InjectFault makes the server misbehave as described by f. When several
+// faults apply to a request, the one injected first wins.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{Fault: f})
+}
+
+// ClearFaults removes the injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// fault returns the fault to inject into the response to r, if any.
+func (s *Server) fault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var injected *Fault
+	for _, f := range s.faults {
+		if f.Action != "" && f.Action != s.routes[r.URL.Path].action {
+			continue
+		}
+		f.matched++
+		if f.Every > 1 && f.matched%f.Every != 0 {
+			continue
+		}
+		if injected == nil {
+			injected = &f.Fault
+		}
+	}
+	if injected == nil {
+		return nil
+	}
+	f := *injected
+	return &f
+}
+
+func (s *Server) serveFault(w http.ResponseWriter, r *http.Request, f *Fault) {
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	switch f.Kind {
+	case FaultLatency:
+		s.serve(w, r)
+	case FaultDrop:
+		closeConnection(w)
+	case FaultStatus:
+		code := f.StatusCode
+		if code == 0 {
+			code = http.StatusServiceUnavailable
+		}
+		http.Error(w, http.StatusText(code), code)
+	case FaultTruncate:
+		recorder := httptest.NewRecorder()
+		s.serve(recorder, r)
+		body := recorder.Body.Bytes()
+		for name, values := range recorder.Header() {
+			w.Header()[name] = values
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(recorder.Code)
+		w.Write(body[:len(body)/2])
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		closeConnection(w)
+	case FaultNonJSON:
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "status=OK; this is not JSON")
+	case FaultHTML:
+		code := f.StatusCode
+		if code == 0 {
+			code = http.StatusBadGateway
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(code)
+		fmt.Fprintf(w, "<html><head><title>%d %s</title></head><body><center><h1>%d %s</h1></center><hr><center>nginx</center></body></html>",
+			code, http.StatusText(code), code, http.StatusText(code))
+	case FaultAPIError:
+		statusInfo := f.StatusInfo
+		if statusInfo == "" {
+			statusInfo = "daily-transaction-limit-exceeded"
+		}
+		r.ParseForm()
+		writeError(w, r.Form, statusInfo)
+	}
+}
+
+// closeConnection closes the connection of w without completing the response.
+func closeConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic("alchemytest: the connection cannot be closed")
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic("alchemytest: " + err.Error())
+	}
+	conn.Close()
+}
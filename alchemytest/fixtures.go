@@ -0,0 +1,133 @@
+package alchemytest
+
+// fixtures are the bodies of the OK responses of every action, without the
+// status, usage, url, language, text and totalTransactions fields the server adds.
+var fixtures = map[string]string{
+	"sentiment": `{
+		"docSentiment": {"type": "negative", "score": "-0.612451", "mixed": "1"}
+	}`,
+	"sentiment_targeted": `{
+		"docSentiment": {"type": "negative", "score": "-0.701862"}
+	}`,
+	"author": `{
+		"author": "Jonathan Weisman"
+	}`,
+	"keywords": `{
+		"keywords": [
+			{"text": "silly sentence", "relevance": "0.984948", "sentiment": {"type": "negative", "score": "-0.537284"}},
+			{"text": "Ruby SDK", "relevance": "0.800123", "sentiment": {"type": "neutral"}},
+			{"text": "Bob", "relevance": "0.513467", "sentiment": {"type": "negative", "score": "-0.612451"}},
+			{"text": "heart", "relevance": "0.490001", "sentiment": {"type": "negative", "score": "-0.612451"}}
+		]
+	}`,
+	"concepts": `{
+		"concepts": [
+			{"text": "Ruby", "relevance": "0.915631", "website": "https://www.ruby-lang.org/",
+				"dbpedia": "http://dbpedia.org/resource/Ruby_(programming_language)",
+				"freebase": "http://rdf.freebase.com/ns/m.06ff5",
+				"opencyc": "http://sw.opencyc.org/concept/Mx4rv4bD3YNfEdqAAAACs6hRjg"}
+		]
+	}`,
+	"entities": `{
+		"entities": [
+			{"type": "Person", "relevance": "0.33", "count": "1", "text": "Bob",
+				"sentiment": {"type": "negative", "score": "-0.612451"}},
+			{"type": "ProgrammingLanguage", "relevance": "0.33", "count": "1", "text": "Ruby",
+				"disambiguated": {"name": "Ruby (programming language)", "subType": ["ProgrammingLanguage", "SoftwareLicense"],
+					"website": "https://www.ruby-lang.org/",
+					"dbpedia": "http://dbpedia.org/resource/Ruby_(programming_language)",
+					"freebase": "http://rdf.freebase.com/ns/m.06ff5"}}
+		]
+	}`,
+	"category": `{
+		"category": "computer_internet",
+		"score": "0.721603"
+	}`,
+	"relations": `{
+		"relations": [
+			{"subject": {"text": "Bob"},
+				"action": {"text": "broke", "lemmatized": "break", "verb": {"text": "break", "tense": "past"}},
+				"object": {"text": "my heart"}},
+			{"subject": {"text": "Bob"},
+				"action": {"text": "made up", "lemmatized": "make up", "verb": {"text": "make", "tense": "past"}},
+				"object": {"text": "this silly sentence"}}
+		]
+	}`,
+	"language": `{
+		"iso-639-1": "en",
+		"iso-639-2": "eng",
+		"iso-639-3": "eng",
+		"ethnologue": "http://www.ethnologue.com/show_language.asp?code=eng",
+		"native-speakers": "309-400 million",
+		"wikipedia": "http://en.wikipedia.org/wiki/English_language"
+	}`,
+	"text": `{
+		"text": "Hello World! My favorite language is Ruby"
+	}`,
+	"text_raw": `{
+		"text": "The best SDK Test | AlchemyAPI Hello World! My favorite language is Ruby"
+	}`,
+	"title": `{
+		"title": "The best SDK Test | AlchemyAPI"
+	}`,
+	"feeds": `{
+		"feeds": [
+			{"feed": "http://www.nytimes.com/services/xml/rss/nyt/HomePage.xml"}
+		]
+	}`,
+	"microformats": `{
+		"microformats": [
+			{"field": "hCard", "data": "AlchemyAPI"}
+		]
+	}`,
+	"taxonomy": `{
+		"taxonomy": [
+			{"label": "/technology and computing/programming languages/ruby", "score": "0.764657"},
+			{"label": "/art and entertainment/music", "score": "0.295462", "confident": "no"}
+		]
+	}`,
+	"combined": `{
+		"title": "The best SDK Test | AlchemyAPI",
+		"author": "Jonathan Weisman",
+		"image": "http://graphics8.nytimes.com/images/2013/07/13/us/politics/13congress/13congress-articleLarge.jpg",
+		"docSentiment": {"type": "negative", "score": "-0.612451"},
+		"entities": [
+			{"type": "Person", "relevance": "0.33", "count": "1", "text": "Bob"}
+		],
+		"keywords": [
+			{"text": "silly sentence", "relevance": "0.984948"}
+		],
+		"concepts": [
+			{"text": "Ruby", "relevance": "0.915631", "dbpedia": "http://dbpedia.org/resource/Ruby_(programming_language)"}
+		],
+		"relations": [
+			{"subject": {"text": "Bob"}, "action": {"text": "broke", "lemmatized": "break", "verb": {"text": "break", "tense": "past"}}, "object": {"text": "my heart"}}
+		],
+		"taxonomy": [
+			{"label": "/technology and computing/programming languages/ruby", "score": "0.764657"}
+		]
+	}`,
+	"image_extract": `{
+		"image": "http://graphics8.nytimes.com/images/2013/07/13/us/politics/13congress/13congress-articleLarge.jpg"
+	}`,
+	"image_tag": `{
+		"imageKeywords": [
+			{"text": "congress", "score": "0.871234"},
+			{"text": "person", "score": "0.650277"}
+		]
+	}`,
+}
+
+// combinedSections maps the values of the extract option of the combined call
+// to the fields of its response.
+var combinedSections = map[string]string{
+	"page-image":    "image",
+	"entity":        "entities",
+	"keyword":       "keywords",
+	"title":         "title",
+	"author":        "author",
+	"taxonomy":      "taxonomy",
+	"concept":       "concepts",
+	"relation":      "relations",
+	"doc-sentiment": "docSentiment",
+}
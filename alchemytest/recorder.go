@@ -0,0 +1,206 @@
+package alchemytest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Modes of a Recorder.
+const (
+	// ModeReplay serves the interactions of the cassette file without calling the service.
+	ModeReplay Mode = iota
+	// ModeRecord calls the service and records the interactions into the cassette file on Save.
+	ModeRecord
+)
+
+type (
+	// Mode is the mode of a Recorder.
+	Mode int
+
+	// Interaction is a recorded request and its response.
+	Interaction struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		// Form is the normalized form of the request, with the apikey removed.
+		Form string `json:"form"`
+		// BodySHA256 is the hash of the raw body of image uploads.
+		BodySHA256  string `json:"bodySHA256,omitempty"`
+		StatusCode  int    `json:"statusCode"`
+		ContentType string `json:"contentType,omitempty"`
+		Body        string `json:"body"`
+	}
+
+	// Recorder is a cassette-style http.RoundTripper. In record mode it sends
+	// requests through the underlying transport and records them, in replay
+	// mode it answers them from the recorded interactions, matching them by
+	// endpoint path and normalized form. Identical requests are answered in
+	// the order they were recorded.
+	//
+	// The apikey parameter is never recorded, so cassettes can be committed.
+	Recorder struct {
+		name      string
+		mode      Mode
+		transport http.RoundTripper
+
+		mu           sync.Mutex
+		interactions []Interaction
+		replayed     map[int]bool
+	}
+)
+
+// NewRecorder returns a Recorder using the cassette file name.
+// In replay mode the file must exist. In record mode requests are sent through
+// transport, or http.DefaultTransport if it is nil.
+func NewRecorder(name string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{name: name, mode: mode, transport: transport, replayed: map[int]bool{}}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	if mode == ModeReplay {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &r.interactions); err != nil {
+			return nil, fmt.Errorf("reading cassette %s: %v", name, err)
+		}
+	}
+	return r, nil
+}
+
+// Client returns an http.Client using the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the recorded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions into the cassette file, creating its directory.
+// It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.name), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.name, append(b, '\n'), 0644)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, req, err := keyOf(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, key)
+	}
+	response, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	key.StatusCode = response.StatusCode
+	key.ContentType = response.Header.Get("Content-Type")
+	key.Body = string(body)
+	r.mu.Lock()
+	r.interactions = append(r.interactions, key)
+	r.mu.Unlock()
+	return response, nil
+}
+
+// replay answers req from the first interaction matching key that was not replayed yet,
+// or from the last matching one once all were.
+func (r *Recorder) replay(req *http.Request, key Interaction) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, interaction := range r.interactions {
+		if interaction.Method != key.Method || interaction.Path != key.Path || interaction.Form != key.Form || interaction.BodySHA256 != key.BodySHA256 {
+			continue
+		}
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("alchemytest: no recorded interaction for %s %s?%s", key.Method, key.Path, key.Form)
+	}
+	r.replayed[match] = true
+	interaction := r.interactions[match]
+	header := http.Header{}
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(interaction.Body)),
+		ContentLength: int64(len(interaction.Body)),
+		Request:       req,
+	}, nil
+}
+
+// keyOf returns the interaction fields identifying req and a copy of req to send,
+// as req, whose body it reads, must not be modified.
+func keyOf(req *http.Request) (Interaction, *http.Request, error) {
+	key := Interaction{Method: req.Method, Path: req.URL.Path}
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return key, nil, err
+		}
+		body = b
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	form := req.URL.Query()
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return key, nil, err
+		}
+		for name, v := range values {
+			form[name] = append(form[name], v...)
+		}
+	} else if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		key.BodySHA256 = hex.EncodeToString(sum[:])
+	}
+	form.Del("apikey")
+	key.Form = form.Encode()
+	return key, req, nil
+}
@@ -0,0 +1,97 @@
+package alchemytest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func TestRecorder(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	server := alchemytest.NewServer()
+	recorder, err := alchemytest.NewRecorder(name, alchemytest.ModeRecord, server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, recorder.Client())
+	recorded, err := client.GetEntities("text", "Bob broke my heart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetImageTagsFromReader(strings.NewReader("\x89PNG")); err != nil {
+		t.Fatal(err)
+	}
+	server.SetError("entities", "unsupported-text-language")
+	if _, err := client.Entities("text", "Bob broke my heart"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), alchemytest.APIKey) {
+		t.Error("the cassette contains the API key")
+	}
+
+	replayer, err := alchemytest.NewRecorder(name, alchemytest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = alchemyapi.New("another-key", server.URL, replayer.Client())
+	replayed, err := client.GetEntities("text", "Bob broke my heart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed.Entities) != len(recorded.Entities) || replayed.Entities[0].Text != recorded.Entities[0].Text {
+		t.Errorf("replayed %+v, recorded %+v", replayed, recorded)
+	}
+	if _, err := client.GetImageTagsFromReader(strings.NewReader("\x89PNG")); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Entities("text", "Bob broke my heart"); err == nil {
+		t.Error("expected the recorded error on the second identical request")
+	}
+	if _, err := client.Entities("text", "something else"); err == nil {
+		t.Error("expected an error for a request that was not recorded")
+	}
+	if _, err := client.GetImageTagsFromReader(strings.NewReader("GIF89a")); err == nil {
+		t.Error("expected an error for an image that was not recorded")
+	}
+}
+
+// The recorder reads the body of requests but, as a RoundTripper, never modifies them.
+func TestRecorderRequestUnmodified(t *testing.T) {
+	server := alchemytest.NewServer()
+	defer server.Close()
+	recorder, err := alchemytest.NewRecorder(filepath.Join(t.TempDir(), "cassette.json"), alchemytest.ModeRecord, server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := ioutil.NopCloser(strings.NewReader("text=Bob&apikey=" + alchemytest.APIKey + "&outputMode=json"))
+	req, err := http.NewRequest("POST", server.URL+"/text/TextGetLanguage", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("status %d", response.StatusCode)
+	}
+	if req.Body != body {
+		t.Error("the body of the request was replaced")
+	}
+}
@@ -0,0 +1,339 @@
+// Package alchemytest provides a local AlchemyAPI emulator for offline development and tests.
+//
+//	server := alchemytest.NewServer()
+//	defer server.Close()
+//	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+package alchemytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+)
+
+// APIKey is the API key accepted by a new Server.
+const APIKey = "alchemytest-api-key"
+
+// Size limits of the content sent to the emulated endpoints, per flavor.
+const (
+	MaxTextSize  = 50 * 1024
+	MaxHTMLSize  = 600 * 1024
+	MaxImageSize = 1024 * 1024
+)
+
+type (
+	// Server is an httptest.Server emulating AlchemyAPI. It serves every
+	// endpoint of the default endpoint table, validates the apikey, outputMode
+	// and flavor parameters like the real service, and answers with fixture
+	// responses or ERROR payloads. Pass its URL as the baseUrl argument of alchemyapi.New.
+	Server struct {
+		*httptest.Server
+
+		// endpoints is the endpoint table the server routes and charges requests by.
+		endpoints *alchemyapi.AlchemyAPI
+
+		mu           sync.Mutex
+		routes       map[string]route
+		keys         map[string]bool
+		responses    map[string]string
+		errors       map[string]string
+		dailyLimit   int
+		transactions int
+		requests     int
+		faults       []*fault
+	}
+
+	// route is the action and flavor served by an endpoint path.
+	route struct {
+		action string
+		flavor string
+	}
+)
+
+// NewServer starts and returns a new Server accepting APIKey.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		routes:    map[string]route{},
+		keys:      map[string]bool{APIKey: true},
+		responses: map[string]string{},
+		errors:    map[string]string{},
+	}
+	endpoints, err := alchemyapi.DefaultEndpoints()
+	if err != nil {
+		panic("alchemytest: " + err.Error())
+	}
+	s.endpoints = endpoints
+	for action, flavors := range endpoints.Endpoints {
+		for flavor, path := range flavors {
+			s.routes[path] = route{action: action, flavor: flavor}
+		}
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddKey makes the server accept key in addition to APIKey.
+func (s *Server) AddKey(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = true
+}
+
+// SetResponse replaces the fixture the action answers with by body, a JSON object.
+// The status, usage, url, language and totalTransactions fields are added to it.
+func (s *Server) SetResponse(action string, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[action] = body
+}
+
+// SetError makes every valid request to the action fail with statusInfo,
+// e.g. "unsupported-text-language". An empty statusInfo clears it.
+func (s *Server) SetError(action string, statusInfo string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if statusInfo == "" {
+		delete(s.errors, action)
+		return
+	}
+	s.errors[action] = statusInfo
+}
+
+// SetDailyLimit makes requests fail with daily-transaction-limit-exceeded once
+// they would take the transactions served past limit. 0 means no limit.
+func (s *Server) SetDailyLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dailyLimit = limit
+}
+
+// Requests returns the number of requests the server received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Transactions returns the number of transactions charged for the requests served OK.
+func (s *Server) Transactions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transactions
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+
+	if f := s.fault(r); f != nil {
+		s.serveFault(w, r, f)
+		return
+	}
+	s.serve(w, r)
+}
+
+// serve answers r like AlchemyAPI.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	rt, ok := s.routes[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	params, data, err := readRequest(r, rt.flavor)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if params.Get("outputMode") != "json" {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><results><status>ERROR</status><statusInfo>unsupported-output-mode</statusInfo></results>`)
+		return
+	}
+	if rt.action == "key_info" {
+		s.serveKeyInfo(w, params)
+		return
+	}
+	if statusInfo := s.validate(rt, params, data); statusInfo != "" {
+		writeError(w, params, statusInfo)
+		return
+	}
+
+	s.mu.Lock()
+	statusInfo := s.errors[rt.action]
+	transactions := s.cost(rt, params)
+	if statusInfo == "" && s.dailyLimit > 0 && s.transactions+transactions > s.dailyLimit {
+		statusInfo = "daily-transaction-limit-exceeded"
+	}
+	if statusInfo == "" {
+		s.transactions += transactions
+	}
+	body, ok := s.responses[rt.action]
+	s.mu.Unlock()
+	if statusInfo != "" {
+		writeError(w, params, statusInfo)
+		return
+	}
+	if !ok {
+		body = fixtures[rt.action]
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		http.Error(w, "alchemytest: invalid fixture: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if rt.action == "combined" {
+		filterCombined(response, params)
+	}
+	response["status"] = "OK"
+	response["usage"] = usage
+	response["url"] = params.Get("url")
+	if rt.action != "image_tag" && rt.action != "image_extract" {
+		response["language"] = "english"
+	}
+	response["totalTransactions"] = fmt.Sprint(transactions)
+	if params.Get("showSourceText") == "1" && rt.flavor == "text" {
+		response["text"] = data
+	}
+	writeJSON(w, response)
+}
+
+// serveKeyInfo answers the API key info call with the transactions served and the daily limit.
+func (s *Server) serveKeyInfo(w http.ResponseWriter, params url.Values) {
+	s.mu.Lock()
+	validKey := s.keys[params.Get("apikey")]
+	consumed, limit := s.transactions, s.dailyLimit
+	s.mu.Unlock()
+	if !validKey {
+		writeError(w, params, "invalid-api-key")
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"status":                    "OK",
+		"usage":                     usage,
+		"consumedDailyTransactions": fmt.Sprint(consumed),
+		"dailyTransactionLimit":     fmt.Sprint(limit),
+	})
+}
+
+// usage is the usage notice AlchemyAPI adds to its responses.
+const usage = "By accessing AlchemyAPI or using information generated by AlchemyAPI, you are agreeing to be bound by the AlchemyAPI Terms of Use: http://www.alchemyapi.com/company/terms.html"
+
+// readRequest returns the parameters of the request and the content sent for flavor.
+// Raw image uploads carry their parameters in the query string.
+func readRequest(r *http.Request, flavor string) (url.Values, string, error) {
+	if flavor == "image" {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, "", err
+		}
+		return r.URL.Query(), string(b), nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, "", err
+	}
+	return r.Form, r.Form.Get(flavor), nil
+}
+
+// validate checks the request like AlchemyAPI does and returns the statusInfo
+// of the error to answer with, or "" if the request is valid.
+func (s *Server) validate(rt route, params url.Values, data string) string {
+	s.mu.Lock()
+	validKey := s.keys[params.Get("apikey")]
+	s.mu.Unlock()
+	if !validKey {
+		return "invalid-api-key"
+	}
+	switch rt.flavor {
+	case "url":
+		u, err := url.Parse(data)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "invalid-url"
+		}
+	case "image":
+		if params.Get("imagePostMode") != "raw" {
+			return "invalid-image-post-mode"
+		}
+	}
+	if strings.TrimSpace(data) == "" {
+		return "content-is-empty"
+	}
+	limit := map[string]int{"text": MaxTextSize, "html": MaxHTMLSize, "image": MaxImageSize}[rt.flavor]
+	if limit > 0 && len(data) > limit {
+		return "content-exceeds-size-limit"
+	}
+	switch rt.action {
+	case "sentiment_targeted":
+		if params.Get("target") == "" {
+			return "target-is-empty"
+		}
+	case "author":
+		// Authors are only found in the metadata of HTML documents.
+		if rt.flavor == "html" && !strings.Contains(strings.ToLower(data), `name="author"`) {
+			return "cannot-locate"
+		}
+	}
+	return ""
+}
+
+// cost returns the transactions charged for a request, from the costs of the endpoint table.
+func (s *Server) cost(rt route, params url.Values) int {
+	options := url.Values{}
+	for name, values := range params {
+		// Set by the client for raw images, not an option of the endpoint.
+		if name != "imagePostMode" {
+			options[name] = values
+		}
+	}
+	transactions, err := s.endpoints.EstimateCost(rt.action, rt.flavor, options)
+	if err != nil {
+		spec, _ := s.endpoints.Spec(rt.action)
+		return spec.Transactions
+	}
+	return transactions
+}
+
+// extractors returns the sections requested from the combined call.
+func extractors(params url.Values) []string {
+	extract := params.Get("extract")
+	if extract == "" {
+		extract = "entity,keyword,title,author,taxonomy,concept"
+	}
+	return strings.Split(extract, ",")
+}
+
+// filterCombined removes the sections that were not requested from a combined response.
+func filterCombined(response map[string]interface{}, params url.Values) {
+	requested := map[string]bool{}
+	for _, extractor := range extractors(params) {
+		requested[combinedSections[strings.TrimSpace(extractor)]] = true
+	}
+	for _, field := range combinedSections {
+		if !requested[field] {
+			delete(response, field)
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, params url.Values, statusInfo string) {
+	writeJSON(w, map[string]interface{}{
+		"status":     "ERROR",
+		"statusInfo": statusInfo,
+		"usage":      usage,
+		"url":        params.Get("url"),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
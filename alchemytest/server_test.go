@@ -0,0 +1,107 @@
+package alchemytest_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func TestServerValidation(t *testing.T) {
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	for _, test := range []struct {
+		name string
+		call func() error
+		kind alchemyapi.ErrorKind
+	}{
+		{"invalid key", func() error {
+			_, err := alchemyapi.New("wrong", server.URL, server.Client()).Entities("text", "Bob")
+			return err
+		}, alchemyapi.ErrInvalidAPIKey},
+		{"empty text", func() error {
+			_, err := client.Entities("text", " ")
+			return err
+		}, alchemyapi.ErrContentIsEmpty},
+		{"invalid url", func() error {
+			_, err := client.Entities("url", "not a url")
+			return err
+		}, alchemyapi.ErrInvalidURL},
+		{"text too large", func() error {
+			_, err := client.Keywords("text", strings.Repeat("a", alchemytest.MaxTextSize+1))
+			return err
+		}, alchemyapi.ErrContentExceedsSizeLimit},
+		{"no author", func() error {
+			_, err := client.Author("html", "<html><body>Hi</body></html>")
+			return err
+		}, alchemyapi.ErrCannotLocate},
+	} {
+		if err := test.call(); !errors.Is(err, test.kind) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.kind)
+		}
+	}
+
+	response, err := http.PostForm(server.URL+"/text/TextGetLanguage", url.Values{"apikey": {alchemytest.APIKey}, "text": {"Hi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.Header.Get("Content-Type") != "text/xml" {
+		t.Errorf("got %s for the default output mode, want text/xml", response.Header.Get("Content-Type"))
+	}
+	if response, _ := http.Get(server.URL + "/text/TextGetNothing"); response.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d for an unknown path, want 404", response.StatusCode)
+	}
+}
+
+func TestServerResponses(t *testing.T) {
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	entities, err := client.GetEntities("text", "Bob broke my heart", url.Values{"sentiment": {"1"}, "showSourceText": {"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entities.Entities) == 0 || entities.Text != "Bob broke my heart" || entities.TotalTransactions != 2 {
+		t.Errorf("unexpected entities response %+v", entities)
+	}
+	combined, err := client.GetCombined("url", "http://example.com/", url.Values{"extract": {"title,page-image"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if combined.Title == "" || combined.Image == "" || combined.Entities != nil || combined.TotalTransactions != 2 {
+		t.Errorf("unexpected combined response %+v", combined)
+	}
+	if server.Transactions() != 4 || server.Requests() != 2 {
+		t.Errorf("got %d transactions in %d requests, want 4 in 2", server.Transactions(), server.Requests())
+	}
+	tags, err := client.GetImageTagsFromReader(strings.NewReader("\x89PNG"))
+	if err != nil || len(tags.ImageKeywords) == 0 {
+		t.Errorf("unexpected image tags response %+v, %v", tags, err)
+	}
+
+	server.SetResponse("title", `{"title": "Custom"}`)
+	title, err := client.GetTitle("url", "http://example.com/")
+	if err != nil || title.Title != "Custom" || title.URL != "http://example.com/" {
+		t.Errorf("unexpected title response %+v, %v", title, err)
+	}
+	server.SetError("language", "unsupported-text-language")
+	if _, err := client.Language("text", "Bob"); !errors.Is(err, alchemyapi.ErrUnsupportedTextLanguage) {
+		t.Errorf("got error %v, want unsupported-text-language", err)
+	}
+	server.SetError("language", "")
+	server.SetDailyLimit(server.Transactions() + 1)
+	if _, err := client.Language("text", "Bob"); err != nil {
+		t.Errorf("got error %v within the daily limit", err)
+	}
+	if _, err := client.Language("text", "Bob"); !errors.Is(err, alchemyapi.ErrDailyTransactionLimitExceeded) {
+		t.Errorf("got error %v, want daily-transaction-limit-exceeded", err)
+	}
+}
@@ -0,0 +1,26 @@
+package alchemyapi
+
+import (
+	"context"
+	"io"
+	"net/url"
+)
+
+// Analyzer is implemented by *Client. Depend on it instead of *Client to
+// substitute fakes in tests or to wrap a client with decorators such as caches or metrics.
+type Analyzer interface {
+	// EndpointAnalyzer has the methods generated for the calls of endpoints.json.
+	EndpointAnalyzer
+	Analyze(ep string, options url.Values) (Result, error)
+	AnalyzeContext(ctx context.Context, ep string, options url.Values) (Result, error)
+	GetImageTagsFromReader(image io.Reader, options ...url.Values) (*ImageTagsResponse, error)
+	GetImageTagsFromReaderContext(ctx context.Context, image io.Reader, options ...url.Values) (*ImageTagsResponse, error)
+	GetExtractedImageTags(flavor string, data string, options ...url.Values) (*ImageExtractResponse, *ImageTagsResponse, error)
+	GetExtractedImageTagsContext(ctx context.Context, flavor string, data string, options ...url.Values) (*ImageExtractResponse, *ImageTagsResponse, error)
+	KeyInfo() (*KeyInfoResponse, error)
+	KeyInfoContext(ctx context.Context) (*KeyInfoResponse, error)
+	Ping() error
+	PingContext(ctx context.Context) error
+}
+
+var _ Analyzer = (*Client)(nil)
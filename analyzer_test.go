@@ -0,0 +1,36 @@
+package alchemyapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// countingAnalyzer decorates an Analyzer, counting the entities calls.
+type countingAnalyzer struct {
+	Analyzer
+	entities int
+}
+
+func (c *countingAnalyzer) Entities(flavor string, data string, options ...url.Values) (Result, error) {
+	c.entities++
+	return c.Analyzer.Entities(flavor, data, options...)
+}
+
+func TestAnalyzerDecorator(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK","entities":[]}`))
+	}))
+	defer server.Close()
+	counter := &countingAnalyzer{Analyzer: New("key", server.URL, server.Client())}
+	var analyzer Analyzer = counter
+
+	response, err := analyzer.Entities("text", "Bob")
+	assert.Equal(nil, err)
+	assert.Equal("OK", response["status"])
+	_, err = analyzer.GetKeywords("text", "Bob")
+	assert.Equal(nil, err)
+	assert.Equal(1, counter.entities)
+}
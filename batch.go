@@ -0,0 +1,344 @@
+package alchemyapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of items a batch analyzes at a time unless set otherwise.
+const DefaultBatchConcurrency = 4
+
+type (
+	// BatchItem is a document of a batch.
+	BatchItem struct {
+		// ID identifies the item in its result, its index in the input if empty.
+		ID string
+		// Flavor and Data are the content to analyze, e.g. "url" and "http://...".
+		Flavor string
+		Data   string
+		// Options are the options of the item per action, e.g. {"entities": {"maxRetrieve": {"10"}}}.
+		Options map[string]url.Values
+	}
+
+	// BatchConfig configures a batch.
+	BatchConfig struct {
+		// Actions are the actions run on every item, e.g. "entities" and "keywords".
+		Actions []string
+		// Concurrency is the number of items analyzed at a time, DefaultBatchConcurrency if 0.
+		// The client rate limits, if any, still apply.
+		Concurrency int
+		// Ordered makes results come in the order of the input instead of the order of completion.
+		Ordered bool
+		// Progress, if set, is called after every item, never concurrently.
+		Progress func(BatchProgress)
+
+		// JobID, if set, makes the batch a resumable job: the outcome of every item is
+		// recorded in the journal JournalDir/JobID.jsonl, and a later batch with the same
+		// JobID reuses the results recorded instead of calling the service again.
+		// Items are matched by ID, which should be set so that it does not depend on the
+		// input order. JournalDir defaults to the current directory.
+		JobID      string
+		JournalDir string
+		// RetryOn reports whether an item that failed with err is attempted again by
+		// the next batch of the job (default RetryFailedItem). Items that failed on
+		// the daily quota always are.
+		RetryOn func(err error) bool
+		// MaxAttempts is the number of batches of the job an item is attempted in before
+		// its error is final, not counting quota errors. 0 means no limit.
+		MaxAttempts int
+	}
+
+	// BatchProgress is the progress of a batch.
+	BatchProgress struct {
+		// Done is the number of items analyzed, of which Failed had an error.
+		Done   int
+		Failed int
+		// Resumed is the number of items whose result was read from the journal of the job.
+		Resumed int
+		// Total is the number of items of the batch, -1 if unknown.
+		Total int
+	}
+
+	// BatchResult is the result of a batch item.
+	BatchResult struct {
+		Item BatchItem
+		// Index is the position of the item in the input.
+		Index int
+		// Results are the responses of the actions that succeeded.
+		Results map[string]Result
+		// Errors are the errors of the actions that failed.
+		Errors map[string]error
+		// Err is the error of the first failed action, in the order of BatchConfig.Actions.
+		Err error
+		// Resumed reports whether the result was read from the journal of the job.
+		// The errors read from the journal only keep their message.
+		Resumed bool
+
+		// attempts is the number of failed runs of the item before this one.
+		attempts int
+	}
+)
+
+// Batch runs the actions of config on the items received from items with bounded
+// concurrency and sends a result per item on the returned channel, which is closed
+// once items is closed and every item is done. Items fail individually: their errors
+// are reported in their results.
+// When ctx ends, Batch stops reading items and closes the channel without sending
+// the results of the items in progress. After the daily transaction limit or the
+// budget of the client is reached, the remaining items fail without calling the service.
+func (a *Client) Batch(ctx context.Context, items <-chan BatchItem, config BatchConfig) (<-chan BatchResult, error) {
+	return a.batch(ctx, items, -1, config)
+}
+
+// BatchItems is like Batch but analyzes the items of a slice.
+func (a *Client) BatchItems(ctx context.Context, items []BatchItem, config BatchConfig) (<-chan BatchResult, error) {
+	input := make(chan BatchItem)
+	results, err := a.batch(ctx, input, len(items), config)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(input)
+		for _, item := range items {
+			select {
+			case input <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results, nil
+}
+
+func (a *Client) batch(ctx context.Context, items <-chan BatchItem, total int, config BatchConfig) (<-chan BatchResult, error) {
+	if len(config.Actions) == 0 {
+		return nil, errors.New("batch: no actions")
+	}
+	for _, action := range config.Actions {
+		if len(a.api.flavors(action)) == 0 {
+			return nil, fmt.Errorf("batch: unknown action %s", action)
+		}
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultBatchConcurrency
+	}
+	if config.RetryOn == nil {
+		config.RetryOn = RetryFailedItem
+	}
+	run := &batchRun{client: a, config: config}
+	if config.JobID != "" {
+		path, err := journalPath(config.JournalDir, config.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if run.journal, err = openJournal(path); err != nil {
+			return nil, err
+		}
+	}
+	results := make(chan BatchResult)
+	go run.run(ctx, items, total, results)
+	return results, nil
+}
+
+// batchRun is a run of a batch.
+type batchRun struct {
+	client  *Client
+	config  BatchConfig
+	journal *journal
+
+	mu sync.Mutex
+	// quotaErr is the first quota error of the run, after which no call is made.
+	quotaErr error
+}
+
+// run analyzes the items with config.Concurrency workers and sends their results,
+// closing results when done.
+func (b *batchRun) run(ctx context.Context, items <-chan BatchItem, total int, results chan<- BatchResult) {
+	defer close(results)
+	if b.journal != nil {
+		defer b.journal.Close()
+	}
+	type job struct {
+		index int
+		item  BatchItem
+	}
+	jobs := make(chan job)
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			var (
+				item BatchItem
+				ok   bool
+			)
+			select {
+			case item, ok = <-items:
+			case <-ctx.Done():
+				return
+			}
+			if !ok {
+				return
+			}
+			select {
+			case jobs <- job{index, item}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	done := make(chan BatchResult)
+	var wg sync.WaitGroup
+	for i := 0; i < b.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				done <- b.item(ctx, j.index, j.item)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Results are drained even after ctx ends so that the workers can finish.
+	send := func(r BatchResult) {
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case results <- r:
+		case <-ctx.Done():
+		}
+	}
+	progress := BatchProgress{Total: total}
+	pending := map[int]BatchResult{}
+	next := 0
+	for r := range done {
+		b.record(r)
+		progress.Done++
+		if r.Err != nil {
+			progress.Failed++
+		}
+		if r.Resumed {
+			progress.Resumed++
+		}
+		if b.config.Progress != nil {
+			b.config.Progress(progress)
+		}
+		if !b.config.Ordered {
+			send(r)
+			continue
+		}
+		pending[r.Index] = r
+		for r, ok := pending[next]; ok; r, ok = pending[next] {
+			delete(pending, next)
+			next++
+			send(r)
+		}
+	}
+}
+
+// item runs the actions on the item, except those done in previous runs of the job.
+func (b *batchRun) item(ctx context.Context, index int, item BatchItem) BatchResult {
+	if item.ID == "" {
+		item.ID = strconv.Itoa(index)
+	}
+	var previous journalEntry
+	if b.journal != nil {
+		previous = b.journal.entries[item.ID]
+		if previous.done(b.config.Actions, b.config.MaxAttempts) {
+			return previous.result(index, item, b.config.Actions)
+		}
+	}
+	r := BatchResult{Item: item, Index: index, Results: map[string]Result{}, Errors: map[string]error{}}
+	for _, action := range b.config.Actions {
+		if result, ok := previous.Results[action]; ok {
+			r.Results[action] = result
+			continue
+		}
+		err := b.stopped()
+		if err == nil {
+			var options []url.Values
+			if o, ok := item.Options[action]; ok {
+				options = append(options, o)
+			}
+			var result Result
+			result, err = b.client.analyze(ctx, action, item.Flavor, item.Data, options...)
+			if err == nil {
+				r.Results[action] = result
+				continue
+			}
+			if quotaError(err) {
+				b.stop(err)
+			}
+		}
+		r.Errors[action] = err
+		if r.Err == nil {
+			r.Err = fmt.Errorf("%s: %w", action, err)
+		}
+	}
+	r.attempts = previous.Attempts
+	return r
+}
+
+// stop stops the calls of the run after the quota error err.
+func (b *batchRun) stop(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.quotaErr == nil {
+		b.quotaErr = err
+	}
+}
+
+// stopped returns the quota error that stopped the run, if any.
+func (b *batchRun) stopped() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.quotaErr
+}
+
+// record writes the result to the journal of the job, if any. Results read from
+// the journal are not recorded. The actions of items interrupted by the end of the
+// context are left out, so that they are run by the next batch of the job, and the
+// interruption does not count as an attempt.
+func (b *batchRun) record(r BatchResult) {
+	if b.journal == nil || r.Resumed {
+		return
+	}
+	entry := journalEntry{ID: r.Item.ID, Results: r.Results, Attempts: r.attempts, Retry: true}
+	quota, interrupted := false, false
+	for action, err := range r.Errors {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			interrupted = true
+			continue
+		}
+		if entry.Errors == nil {
+			entry.Errors = map[string]string{}
+		}
+		entry.Errors[action] = err.Error()
+		if quotaError(err) {
+			quota = true
+		} else if !b.config.RetryOn(err) {
+			entry.Retry = false
+		}
+	}
+	if len(entry.Results) == 0 && len(entry.Errors) == 0 {
+		return
+	}
+	// Quota errors are not the item's fault, the item is attempted again once the quota resets.
+	if len(entry.Errors) != 0 && !quota && !interrupted {
+		entry.Attempts++
+	}
+	if len(entry.Errors) == 0 {
+		entry.Retry = false
+	}
+	if err := b.journal.append(entry); err != nil {
+		b.client.logf("alchemyapi: batch %s: recording item %s: %v", b.config.JobID, r.Item.ID, err)
+	}
+}
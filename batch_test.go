@@ -0,0 +1,99 @@
+package alchemyapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func batchItems(n int) []alchemyapi.BatchItem {
+	items := make([]alchemyapi.BatchItem, n)
+	for i := range items {
+		items[i] = alchemyapi.BatchItem{Flavor: "text", Data: fmt.Sprintf("Bob %d broke my heart", i)}
+	}
+	return items
+}
+
+func TestBatch(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	items := batchItems(20)
+	items[3].ID = "bad"
+	items[3].Options = map[string]url.Values{"entities": {"maxRetrieve": {"many"}}}
+	var progress []alchemyapi.BatchProgress
+	results, err := client.BatchItems(context.Background(), items, alchemyapi.BatchConfig{
+		Actions:     []string{"entities", "keywords"},
+		Concurrency: 5,
+		Ordered:     true,
+		Progress:    func(p alchemyapi.BatchProgress) { progress = append(progress, p) },
+	})
+	assert.Equal(nil, err)
+	index := 0
+	for r := range results {
+		assert.Equal(index, r.Index)
+		if index == 3 {
+			assert.Equal("bad", r.Item.ID)
+			var optionErr *alchemyapi.OptionError
+			assert.Equal(true, errors.As(r.Err, &optionErr))
+			assert.Equal(true, errors.As(r.Errors["entities"], &optionErr))
+			assert.NotNil(r.Results["keywords"])
+		} else {
+			assert.Equal(fmt.Sprint(index), r.Item.ID)
+			assert.Equal(nil, r.Err)
+			assert.Equal(2, len(r.Results))
+		}
+		index++
+	}
+	assert.Equal(20, index)
+	assert.Equal(20, len(progress))
+	assert.Equal(alchemyapi.BatchProgress{Done: 20, Failed: 1, Total: 20}, progress[19])
+	assert.Equal(39, server.Transactions())
+
+	input := make(chan alchemyapi.BatchItem)
+	results, err = client.Batch(context.Background(), input, alchemyapi.BatchConfig{Actions: []string{"language"}})
+	assert.Equal(nil, err)
+	go func() {
+		for _, item := range batchItems(10) {
+			input <- item
+		}
+		close(input)
+	}()
+	seen := map[int]bool{}
+	for r := range results {
+		assert.Equal(nil, r.Err)
+		seen[r.Index] = true
+	}
+	assert.Equal(10, len(seen))
+
+	_, err = client.Batch(context.Background(), input, alchemyapi.BatchConfig{})
+	assert.Equal("batch: no actions", err.Error())
+	_, err = client.Batch(context.Background(), input, alchemyapi.BatchConfig{Actions: []string{"sentiments"}})
+	assert.Equal("batch: unknown action sentiments", err.Error())
+}
+
+func TestBatchCancel(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := client.BatchItems(ctx, batchItems(100), alchemyapi.BatchConfig{Actions: []string{"entities"}, Concurrency: 2})
+	assert.Equal(nil, err)
+	n := 0
+	for range results {
+		if n++; n == 5 {
+			cancel()
+		}
+	}
+	assert.Equal(true, n < 100)
+}
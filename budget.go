@@ -0,0 +1,191 @@
+package alchemyapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned by the calls of a client whose Budget is spent.
+var ErrBudgetExceeded = errors.New("daily transaction budget exceeded")
+
+// Budget tracks the transactions consumed with an API key per UTC day in a file,
+// so that the services sharing the key share its daily limit across restarts.
+// A client created with WithBudget reserves the estimated cost of every call
+// before sending it, and records the totalTransactions of the response, if any, after it.
+// Calls that would leave fewer than Reserve transactions of Limit fail with
+// ErrBudgetExceeded, or wait for the budget to free up if Wait is set.
+// The file is locked while it is updated, so a Budget is safe for concurrent use
+// by multiple goroutines and, on unix systems, multiple processes.
+type Budget struct {
+	// Path is the file the consumed transactions are kept in. It is created if missing.
+	Path string
+	// Limit is the daily transaction limit of the API key.
+	Limit int
+	// Reserve is the number of transactions left for others, e.g. interactive services.
+	Reserve int
+	// Wait makes calls over budget wait until the next UTC day, or until other calls
+	// give back their estimates, instead of failing. Calls costing more than
+	// Limit-Reserve still fail.
+	Wait bool
+
+	mu sync.Mutex
+	// now and poll are replaced in tests.
+	now  func() time.Time
+	poll time.Duration
+}
+
+// budgetFile is the content of the budget file.
+type budgetFile struct {
+	Day          string `json:"day"`
+	Transactions int    `json:"transactions"`
+}
+
+// Used returns the transactions consumed today.
+func (b *Budget) Used() (int, error) {
+	return b.update(func(day string, used int) (int, error) { return used, nil })
+}
+
+// Remaining returns the transactions that calls can still consume today.
+func (b *Budget) Remaining() (int, error) {
+	used, err := b.Used()
+	return b.Limit - b.Reserve - used, err
+}
+
+// acquire reserves n transactions, waiting for them if b.Wait is set, and returns
+// the UTC day they were reserved on. Calls costing more than the budget of a whole
+// day fail right away, as waiting cannot help them.
+func (b *Budget) acquire(ctx context.Context, n int) (string, error) {
+	if n > b.Limit-b.Reserve {
+		return "", fmt.Errorf("%w: a call of %d transactions exceeds the daily limit of %d, %d reserved", ErrBudgetExceeded, n, b.Limit, b.Reserve)
+	}
+	for {
+		var reserved string
+		_, err := b.update(func(day string, used int) (int, error) {
+			if used+n > b.Limit-b.Reserve {
+				return used, fmt.Errorf("%w: %d of %d transactions used, %d reserved", ErrBudgetExceeded, used, b.Limit, b.Reserve)
+			}
+			reserved = day
+			return used + n, nil
+		})
+		if err == nil || !b.Wait || !errors.Is(err, ErrBudgetExceeded) {
+			return reserved, err
+		}
+		now := b.clock()
+		wait := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(now)
+		if poll := b.pollInterval(); wait > poll {
+			wait = poll
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// settle replaces the estimate reserved on day for a call by what it cost: the
+// totalTransactions of its response, nothing if it failed without one, or the estimate.
+// Calls settled on another day than their reservation are not corrected, as the
+// count of the day they were reserved on is gone.
+// A daily-transaction-limit-exceeded error spends the budget for the day.
+func (b *Budget) settle(day string, estimate int, transactions int, callErr error) error {
+	_, err := b.update(func(today string, used int) (int, error) {
+		switch {
+		case errors.Is(callErr, ErrDailyTransactionLimitExceeded):
+			used = b.Limit
+		case today != day:
+		case transactions > 0:
+			used += transactions - estimate
+		case callErr != nil:
+			used -= estimate
+		}
+		if used < 0 {
+			used = 0
+		}
+		return used, nil
+	})
+	return err
+}
+
+// update applies f to the UTC day and the transactions used on it with the file locked, and writes the result.
+func (b *Budget) update(f func(day string, used int) (int, error)) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lock, err := os.OpenFile(b.Path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer lock.Close()
+	if err := lockFile(lock); err != nil {
+		return 0, err
+	}
+	defer unlockFile(lock)
+
+	day := b.clock().UTC().Format("2006-01-02")
+	var state budgetFile
+	content, err := os.ReadFile(b.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if len(content) != 0 {
+		if err := json.Unmarshal(content, &state); err != nil {
+			return 0, fmt.Errorf("reading budget %s: %v", b.Path, err)
+		}
+	}
+	used := 0
+	if state.Day == day {
+		used = state.Transactions
+	}
+	next, err := f(day, used)
+	if next == used && state.Day == day {
+		return used, err
+	}
+	if writeErr := b.write(budgetFile{Day: day, Transactions: next}); writeErr != nil {
+		return used, writeErr
+	}
+	return next, err
+}
+
+// write replaces the budget file atomically.
+func (b *Budget) write(state budgetFile) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(b.Path), filepath.Base(b.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), b.Path)
+}
+
+func (b *Budget) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+func (b *Budget) pollInterval() time.Duration {
+	if b.poll > 0 {
+		return b.poll
+	}
+	return time.Minute
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package alchemyapi
+
+import "os"
+
+// lockFile does not lock f across processes on this platform; a Budget is then
+// only safe for concurrent use within a process.
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
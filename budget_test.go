@@ -0,0 +1,152 @@
+package alchemyapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBudget(t *testing.T) {
+	assert := NewAssert(t)
+	path := filepath.Join(t.TempDir(), "budget.json")
+	day := time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC)
+	now := func() time.Time { return day }
+	// Two budgets on the same file, as in two processes.
+	first := &Budget{Path: path, Limit: 10, Reserve: 2, now: now}
+	second := &Budget{Path: path, Limit: 10, Reserve: 2, now: now}
+
+	reserved, err := first.acquire(context.Background(), 5)
+	assert.Equal(nil, err)
+	assert.Equal("2026-10-17", reserved)
+	_, err = second.acquire(context.Background(), 3)
+	assert.Equal(nil, err)
+	used, err := first.Used()
+	assert.Equal(nil, err)
+	assert.Equal(8, used)
+	remaining, _ := second.Remaining()
+	assert.Equal(0, remaining)
+	_, err = second.acquire(context.Background(), 1)
+	assert.Equal(true, errors.Is(err, ErrBudgetExceeded))
+	assert.Equal("daily transaction budget exceeded: 8 of 10 transactions used, 2 reserved", err.Error())
+
+	assert.Equal(nil, first.settle("2026-10-17", 5, 2, nil))
+	used, _ = second.Used()
+	assert.Equal(5, used)
+	assert.Equal(nil, first.settle("2026-10-17", 3, 0, errors.New("connection reset")))
+	used, _ = second.Used()
+	assert.Equal(2, used)
+	assert.Equal(nil, first.settle("2026-10-17", 1, 0, &APIError{Kind: ErrDailyTransactionLimitExceeded}))
+	used, _ = second.Used()
+	assert.Equal(10, used)
+
+	// The budget starts over every UTC day.
+	day = day.Add(2 * time.Hour)
+	used, _ = first.Used()
+	assert.Equal(0, used)
+	_, err = first.acquire(context.Background(), 1)
+	assert.Equal(nil, err)
+	content, err := os.ReadFile(path)
+	assert.Equal(nil, err)
+	assert.Equal(`{"day":"2026-10-18","transactions":1}`, string(content))
+	// A call reserved the day before is not corrected against the new day.
+	assert.Equal(nil, first.settle("2026-10-17", 5, 0, errors.New("connection reset")))
+	used, _ = first.Used()
+	assert.Equal(1, used)
+
+	assert.Equal(nil, os.WriteFile(path, []byte("{"), 0644))
+	_, err = first.Used()
+	assert.NotNil(err)
+}
+
+func TestBudgetConcurrent(t *testing.T) {
+	assert := NewAssert(t)
+	path := filepath.Join(t.TempDir(), "budget.json")
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		acquired int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A budget per goroutine, sharing only the file.
+			b := &Budget{Path: path, Limit: 10}
+			if _, err := b.acquire(context.Background(), 1); err == nil {
+				mu.Lock()
+				acquired++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(10, acquired)
+	used, _ := (&Budget{Path: path, Limit: 10}).Used()
+	assert.Equal(10, used)
+}
+
+func TestBudgetWait(t *testing.T) {
+	assert := NewAssert(t)
+	b := &Budget{Path: filepath.Join(t.TempDir(), "budget.json"), Limit: 2, Wait: true, poll: 5 * time.Millisecond}
+	day, err := b.acquire(context.Background(), 2)
+	assert.Equal(nil, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := b.acquire(context.Background(), 1)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(nil, b.settle(day, 2, 1, nil))
+	assert.Equal(nil, <-done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = b.acquire(ctx, 1)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	// A call costing more than a day of budget fails instead of waiting forever.
+	_, err = b.acquire(context.Background(), 3)
+	assert.Equal(true, errors.Is(err, ErrBudgetExceeded))
+}
+
+func TestClientBudget(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("text") {
+		case "error":
+			w.Write([]byte(`{"status":"ERROR","statusInfo":"cannot-locate"}`))
+		case "limit":
+			w.Write([]byte(`{"status":"ERROR","statusInfo":"daily-transaction-limit-exceeded"}`))
+		default:
+			w.Write([]byte(`{"status":"OK","totalTransactions":"3"}`))
+		}
+	}))
+	defer server.Close()
+	b := &Budget{Path: filepath.Join(t.TempDir(), "budget.json"), Limit: 10, Reserve: 4}
+	a, err := NewClient("key", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithBudget(b))
+	assert.Equal(nil, err)
+
+	_, err = a.Entities("text", "Bob")
+	assert.Equal(nil, err)
+	used, _ := b.Used()
+	assert.Equal(3, used)
+	_, err = a.Entities("text", "error")
+	assert.Equal(true, errors.Is(err, ErrCannotLocate))
+	used, _ = b.Used()
+	assert.Equal(3, used)
+	_, err = a.Relations("text", "Bob", RelationsOptions{Sentiment: Bool(true), Keywords: Bool(true), Entities: Bool(true)}.Values())
+	assert.Equal(true, errors.Is(err, ErrBudgetExceeded))
+	_, err = a.Entities("text", "limit")
+	assert.Equal(true, errors.Is(err, ErrDailyTransactionLimitExceeded))
+	used, _ = b.Used()
+	assert.Equal(10, used)
+	_, err = a.Entities("text", "Bob")
+	assert.Equal(true, errors.Is(err, ErrBudgetExceeded))
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package alchemyapi
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on f, shared with other processes.
+func lockFile(f *os.File) error {
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,196 @@
+package alchemyapi
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// DefaultBaseURL is the base URL of the public AlchemyAPI service.
+const DefaultBaseURL = "http://access.alchemyapi.com/calls"
+
+type (
+	// Option configures a Client created with NewClient.
+	Option func(*clientConfig) error
+
+	// Logger receives the diagnostics of a client, such as retried attempts.
+	// *log.Logger implements it.
+	Logger interface {
+		Printf(format string, v ...interface{})
+	}
+
+	// clientConfig collects the options of NewClient.
+	clientConfig struct {
+		client  *Client
+		timeout time.Duration
+	}
+)
+
+// NewClient returns a client for the given API key, configured by opts.
+// By default it calls DefaultBaseURL with an http.Client that has a 60s
+// timeout and a pooled transport, and it makes a single attempt per call.
+func NewClient(key string, opts ...Option) (*Client, error) {
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	config := &clientConfig{client: New(key, DefaultBaseURL, nil)}
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, err
+		}
+	}
+	if config.timeout > 0 {
+		// Copied so that the timeout does not change a client passed with WithHTTPClient.
+		httpClient := *config.client.httpClient
+		httpClient.Timeout = config.timeout
+		config.client.httpClient = &httpClient
+	}
+	return config.client, nil
+}
+
+// WithBaseURL sets the base URL the endpoint paths are appended to. Empty means DefaultBaseURL.
+func WithBaseURL(baseUrl string) Option {
+	return func(c *clientConfig) error {
+		if baseUrl == "" {
+			baseUrl = DefaultBaseURL
+		}
+		c.client.baseUrl = baseUrl
+		return nil
+	}
+}
+
+// WithHTTPClient sets the http.Client requests are sent with. Nil means the default client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *clientConfig) error {
+		if httpClient == nil {
+			httpClient = defaultHTTPClient()
+		}
+		c.client.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every attempt of a call, replacing the
+// timeout of the http.Client. Use a context deadline to bound retried calls.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *clientConfig) error {
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *clientConfig) error {
+		c.client.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithEndpoints reads an endpoint table from r and applies it over the
+// default table, like NewWithEndpoints. Actions given with a spec replace
+// the options, limits and cost of the default table; actions given as paths keep them.
+func WithEndpoints(r io.Reader) Option {
+	return func(c *clientConfig) error {
+		override, err := LoadEndpoints(r)
+		if err != nil {
+			return err
+		}
+		for _, action := range override.Actions() {
+			if override.specs[action] != nil {
+				spec, _ := override.Spec(action)
+				if err := c.client.api.RegisterSpec(action, spec); err != nil {
+					return err
+				}
+				continue
+			}
+			for flavor, path := range override.Endpoints[action] {
+				c.client.api.Register(action, flavor, path)
+			}
+		}
+		return nil
+	}
+}
+
+// WithEndpointsFile is like WithEndpoints but reads the table from the named file.
+func WithEndpointsFile(name string) Option {
+	return func(c *clientConfig) error {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return WithEndpoints(f)(c)
+	}
+}
+
+// WithDryRun makes the calls of the client validate their options and return a
+// *DryRunError with their estimated cost instead of calling the service.
+// Calls that cost no transactions, such as KeyInfo and Ping, are still made.
+func WithDryRun() Option {
+	return func(c *clientConfig) error {
+		c.client.dryRun = true
+		return nil
+	}
+}
+
+// WithBudget makes the client consult b before every call, see Budget.
+// Clients and processes sharing an API key should share the budget file.
+func WithBudget(b *Budget) Option {
+	return func(c *clientConfig) error {
+		c.client.budget = b
+		return nil
+	}
+}
+
+// WithRetry sets the retry policy, see SetRetryPolicy.
+func WithRetry(p *RetryPolicy) Option {
+	return func(c *clientConfig) error {
+		c.client.retry = p
+		return nil
+	}
+}
+
+// WithMaxResponseSize sets the largest response body accepted, see SetMaxResponseSize.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *clientConfig) error {
+		c.client.maxResponseSize = n
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the client diagnostics. By default nothing is logged.
+func WithLogger(logger Logger) Option {
+	return func(c *clientConfig) error {
+		c.client.logger = logger
+		return nil
+	}
+}
+
+// defaultHTTPClient returns the http.Client used when none is given.
+func defaultHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 60 * time.Second,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   10 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   16,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
+
+// logf logs to the logger of the client, if any.
+func (a *Client) logf(format string, v ...interface{}) {
+	if a.logger != nil {
+		a.logger.Printf(format, v...)
+	}
+}
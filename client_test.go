@@ -0,0 +1,62 @@
+package alchemyapi
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	assert := NewAssert(t)
+	a, err := NewClient("key")
+	assert.Equal(nil, err)
+	assert.Equal(DefaultBaseURL, a.baseUrl)
+	assert.Equal(60*time.Second, a.httpClient.Timeout)
+	assert.Equal(true, a.retry == nil)
+
+	a = New("key", "", nil)
+	assert.Equal(DefaultBaseURL, a.baseUrl)
+	assert.NotNil(a.httpClient)
+
+	var agents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.UserAgent())
+		if len(agents) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"OK","url":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+	httpClient := server.Client()
+	var logs bytes.Buffer
+	a, err = NewClient("key",
+		WithBaseURL(server.URL),
+		WithHTTPClient(httpClient),
+		WithTimeout(5*time.Second),
+		WithUserAgent("enricher/1.0"),
+		WithEndpoints(strings.NewReader(`{"title":{"url":"/proxy/title"}}`)),
+		WithRetry(&RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		WithMaxResponseSize(1024),
+		WithLogger(log.New(&logs, "", 0)),
+	)
+	assert.Equal(nil, err)
+	assert.Equal(5*time.Second, a.httpClient.Timeout)
+	assert.Equal(time.Duration(0), httpClient.Timeout)
+	assert.Equal(int64(1024), a.maxResponseSize)
+	title, err := a.GetTitle("url", "http://example.com/")
+	assert.Equal(nil, err)
+	assert.Equal("/proxy/title", title.URL)
+	assert.Equal([]string{"enricher/1.0", "enricher/1.0"}, agents)
+	assert.Equal(true, strings.Contains(logs.String(), "attempt 1 failed"))
+
+	_, err = NewClient("key", WithEndpoints(strings.NewReader(`{`)))
+	assert.NotNil(err)
+	_, err = NewClient("key", WithEndpointsFile(filepath.Join(t.TempDir(), "missing.json")))
+	assert.NotNil(err)
+}
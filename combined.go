@@ -0,0 +1,139 @@
+package alchemyapi
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// Extractor is a section of the combined call, passed in its extract option.
+type Extractor string
+
+// Extractors of the combined call.
+const (
+	ExtractPageImage    Extractor = "page-image"
+	ExtractEntity       Extractor = "entity"
+	ExtractKeyword      Extractor = "keyword"
+	ExtractTitle        Extractor = "title"
+	ExtractAuthor       Extractor = "author"
+	ExtractTaxonomy     Extractor = "taxonomy"
+	ExtractConcept      Extractor = "concept"
+	ExtractRelation     Extractor = "relation"
+	ExtractDocSentiment Extractor = "doc-sentiment"
+)
+
+// CombinedRequest builds a combined call. The sections it extracts are populated
+// in the *CombinedResponse fields of the same name, e.g. ExtractEntity in Entities.
+//
+//	r, err := alchemyapi.NewCombinedRequest("url", u).
+//		Extract(alchemyapi.ExtractEntity, alchemyapi.ExtractDocSentiment).
+//		Sentiment(true).
+//		Do(client)
+type CombinedRequest struct {
+	flavor  string
+	data    string
+	options CombinedOptions
+}
+
+// NewCombinedRequest returns a combined call for the url or text flavor.
+// Without Extract, the service extracts its default sections.
+func NewCombinedRequest(flavor string, data string) *CombinedRequest {
+	return &CombinedRequest{flavor: flavor, data: data}
+}
+
+// Extract adds sections to extract.
+func (r *CombinedRequest) Extract(extractors ...Extractor) *CombinedRequest {
+	for _, e := range extractors {
+		r.options.Extract = append(r.options.Extract, string(e))
+	}
+	return r
+}
+
+// WithExtractMode sets how the page image is found.
+func (r *CombinedRequest) WithExtractMode(mode ExtractMode) *CombinedRequest {
+	r.options.ExtractMode = mode
+	return r
+}
+
+// Disambiguate sets whether to disambiguate detected entities.
+func (r *CombinedRequest) Disambiguate(b bool) *CombinedRequest {
+	r.options.Disambiguate = Bool(b)
+	return r
+}
+
+// LinkedData sets whether to include linked data with disambiguated entities.
+func (r *CombinedRequest) LinkedData(b bool) *CombinedRequest {
+	r.options.LinkedData = Bool(b)
+	return r
+}
+
+// Coreference sets whether to resolve he/she/etc coreferences into detected entities.
+func (r *CombinedRequest) Coreference(b bool) *CombinedRequest {
+	r.options.Coreference = Bool(b)
+	return r
+}
+
+// Quotations sets whether to extract quotations.
+func (r *CombinedRequest) Quotations(b bool) *CombinedRequest {
+	r.options.Quotations = Bool(b)
+	return r
+}
+
+// Sentiment sets whether to analyze entity-level sentiment, which costs one more transaction.
+func (r *CombinedRequest) Sentiment(b bool) *CombinedRequest {
+	r.options.Sentiment = Bool(b)
+	return r
+}
+
+// ShowSourceText sets whether to return the analyzed text.
+func (r *CombinedRequest) ShowSourceText(b bool) *CombinedRequest {
+	r.options.ShowSourceText = Bool(b)
+	return r
+}
+
+// MaxRetrieve sets the maximum number of entities to extract.
+func (r *CombinedRequest) MaxRetrieve(n int) *CombinedRequest {
+	r.options.MaxRetrieve = n
+	return r
+}
+
+// Options returns the options of the call, as passed to Combined.
+func (r *CombinedRequest) Options() url.Values {
+	return r.options.Values()
+}
+
+// Validate checks the flavor against the default endpoint table and the options.
+// The html flavor is not available, it returns an *OptionError for it.
+func (r *CombinedRequest) Validate() error {
+	return r.validate(&api)
+}
+
+func (r *CombinedRequest) validate(endpoints *AlchemyAPI) error {
+	if _, ok := endpoints.Path("combined", r.flavor); !ok {
+		if apiErr != nil {
+			return apiErr
+		}
+		return &OptionError{Endpoint: "combined", Option: "flavor", Value: r.flavor, Reason: "not available, use " + strings.Join(endpoints.flavors("combined"), " or ")}
+	}
+	return endpoints.validateOptions("combined", r.Options())
+}
+
+// Do runs the call with a.
+func (r *CombinedRequest) Do(a Analyzer) (*CombinedResponse, error) {
+	return r.DoContext(context.Background(), a)
+}
+
+// DoContext is like Do but uses ctx for the request.
+// The request is validated against the endpoint table of a if it has an
+// Endpoints method, like *Client and decorators embedding one, and against the
+// default table otherwise.
+func (r *CombinedRequest) DoContext(ctx context.Context, a Analyzer) (*CombinedResponse, error) {
+	endpoints := &api
+	if t, ok := a.(interface{ Endpoints() *AlchemyAPI }); ok {
+		endpoints = t.Endpoints()
+	}
+	if err := r.validate(endpoints); err != nil {
+		return nil, err
+	}
+	return a.GetCombinedContext(ctx, r.flavor, r.data, r.Options())
+}
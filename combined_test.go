@@ -0,0 +1,73 @@
+package alchemyapi_test
+
+import (
+	"errors"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func TestCombinedRequest(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	r := alchemyapi.NewCombinedRequest("text", "Bob broke my heart").
+		Extract(alchemyapi.ExtractEntity, alchemyapi.ExtractDocSentiment, alchemyapi.ExtractPageImage).
+		WithExtractMode(alchemyapi.ExtractModeAlwaysInfer).
+		Disambiguate(false).
+		MaxRetrieve(10)
+	assert.Equal("entity,doc-sentiment,page-image", r.Options().Get("extract"))
+	assert.Equal("0", r.Options().Get("disambiguate"))
+	assert.Equal("", r.Options().Get("sentiment"))
+
+	response, err := r.Do(client)
+	assert.Equal(nil, err)
+	assert.Equal("Bob", response.Entities[0].Text)
+	assert.Equal("negative", response.DocSentiment.Type)
+	assert.Equal(true, response.Image != "")
+	assert.Equal(0, len(response.Keywords))
+	assert.Equal("", response.Title)
+
+	var optErr *alchemyapi.OptionError
+	_, err = alchemyapi.NewCombinedRequest("html", "<p>Bob</p>").Extract(alchemyapi.ExtractEntity).Do(client)
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal("flavor", optErr.Option)
+	assert.Equal("combined: option flavor=html: not available, use text or url", err.Error())
+	assert.Equal(true, errors.As(alchemyapi.NewCombinedRequest("html", "<p>Bob</p>").Validate(), &optErr))
+
+	_, err = alchemyapi.NewCombinedRequest("url", "http://example.com/").WithExtractMode("guess").Do(client)
+	assert.Equal(true, errors.As(err, &optErr))
+	_, err = alchemyapi.NewCombinedRequest("url", "http://example.com/").Extract("entities").Do(client)
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal(1, server.Requests())
+}
+
+// decoratedClient is a decorator embedding a client, such as a cache or metrics wrapper.
+type decoratedClient struct {
+	*alchemyapi.Client
+}
+
+func TestCombinedRequestClientTable(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+	spec, _ := client.Endpoints().Spec("combined")
+	option := spec.Options["maxRetrieve"]
+	option.Max = 5
+	spec.Options["maxRetrieve"] = option
+	assert.Equal(nil, client.Endpoints().RegisterSpec("combined", spec))
+
+	r := alchemyapi.NewCombinedRequest("text", "Bob broke my heart").Extract(alchemyapi.ExtractEntity).MaxRetrieve(10)
+	assert.Equal(nil, r.Validate())
+	var optErr *alchemyapi.OptionError
+	_, err := r.Do(client)
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal("maxRetrieve", optErr.Option)
+	_, err = r.Do(decoratedClient{client})
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal(0, server.Requests())
+}
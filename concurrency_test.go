@@ -0,0 +1,84 @@
+package alchemyapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func TestOptionsNotModified(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+	a := New("secret", server.URL, server.Client())
+	options := url.Values{"maxRetrieve": {"5"}}
+
+	_, err := a.Entities("text", "Bob", options)
+	assert.Equal(nil, err)
+	_, err = a.Analyze("/text/TextGetLanguage", options)
+	assert.Equal(nil, err)
+	assert.Equal(url.Values{"maxRetrieve": {"5"}}, options)
+
+	options = url.Values{"showSourceText": {"1"}}
+	_, err = a.SentimentTargeted("text", "Bob", "Bob", options)
+	assert.Equal(nil, err)
+	assert.Equal(url.Values{"showSourceText": {"1"}}, options)
+
+	options = url.Values{"forceShowAll": {"1"}}
+	_, err = a.ImageTags("image", "\x89PNG", options)
+	assert.Equal(nil, err)
+	assert.Equal(url.Values{"forceShowAll": {"1"}}, options)
+}
+
+// Run with -race.
+func TestConcurrentCalls(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("apikey") != "secret" || r.FormValue("maxRetrieve") != "5" {
+			w.Write([]byte(`{"status":"ERROR","statusInfo":"invalid-request"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"status":"OK","text":%q}`, r.FormValue("text"))
+	}))
+	defer server.Close()
+	a := New("secret", server.URL, server.Client())
+	shared := url.Values{"maxRetrieve": {"5"}}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			text := fmt.Sprintf("document %d", i)
+			var (
+				response *TextResponse
+				err      error
+			)
+			if i%2 == 0 {
+				var e *EntitiesResponse
+				e, err = a.GetEntities("text", text, shared)
+				response = &TextResponse{Response: e.Response, Text: e.Text}
+			} else {
+				var k *KeywordsResponse
+				k, err = a.GetKeywords("text", text, shared)
+				response = &TextResponse{Response: k.Response, Text: k.Text}
+			}
+			if err == nil && response.Text != text {
+				err = fmt.Errorf("got response for %q, want %q", response.Text, text)
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.Equal(nil, err)
+	}
+	assert.Equal(url.Values{"maxRetrieve": {"5"}}, shared)
+}
@@ -0,0 +1,42 @@
+package alchemyapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	assert := NewAssert(t)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`{"status":"OK","entities":[]}`))
+	}))
+	defer server.Close()
+	defer close(release)
+	a := New("key", server.URL, server.Client())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := a.EntitiesContext(ctx, "text", "Bob broke my heart")
+	assert.Equal(context.DeadlineExceeded, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	_, err = a.GetKeywordsContext(ctx, "text", "Bob broke my heart")
+	assert.Equal(context.Canceled, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = a.GetImageTagsContext(ctx, "image", "\x89PNG")
+	assert.Equal(context.Canceled, err)
+}
@@ -0,0 +1,81 @@
+package alchemyapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrDryRun matches the *DryRunError returned by the calls of a client in dry-run mode.
+var ErrDryRun = errors.New("dry run")
+
+// DryRunError is returned instead of a response by a client created with WithDryRun.
+// It holds the transactions the call would have cost.
+type DryRunError struct {
+	Endpoint     string
+	Flavor       string
+	Transactions int
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: %s for %s would cost %d transactions", e.Endpoint, e.Flavor, e.Transactions)
+}
+
+func (e *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
+}
+
+// EstimateCost returns the transactions a call of the action for flavor with options
+// would cost, according to the default endpoint table. See AlchemyAPI.EstimateCost.
+func EstimateCost(action string, flavor string, options url.Values) (int, error) {
+	if apiErr != nil {
+		return 0, apiErr
+	}
+	return api.EstimateCost(action, flavor, options)
+}
+
+// EstimateCost returns the transactions a call of the action for flavor with options
+// would cost: the transactions of the action, plus those of every enabled bool
+// option and of every item of list options, such as the extractors of the combined call.
+// It returns an error if the call is not available or the options are invalid.
+// Actions without a spec cost 1 transaction.
+func (api *AlchemyAPI) EstimateCost(action string, flavor string, options url.Values) (int, error) {
+	if _, ok := api.Path(action, flavor); !ok {
+		return 0, fmt.Errorf("%s analysis for %s not available", action, flavor)
+	}
+	if err := api.validateOptions(action, options); err != nil {
+		return 0, err
+	}
+	return api.cost(action, options), nil
+}
+
+// cost returns the transactions of a call of the action with valid options.
+func (api *AlchemyAPI) cost(action string, options url.Values) int {
+	api.mu.RLock()
+	spec := api.specs[action]
+	api.mu.RUnlock()
+	if spec == nil {
+		return 1
+	}
+	transactions := spec.Transactions
+	for name, option := range spec.Options {
+		if option.Transactions == 0 {
+			continue
+		}
+		value, ok := options[name]
+		switch {
+		case option.Type == OptionBool && ok && len(value) > 0 && value[0] == "1":
+			transactions += option.Transactions
+		case option.Type == OptionList:
+			list := option.Default
+			if ok && len(value) > 0 {
+				list = value[0]
+			}
+			if list != "" {
+				transactions += option.Transactions * len(strings.Split(list, ","))
+			}
+		}
+	}
+	return transactions
+}
@@ -0,0 +1,105 @@
+package alchemyapi_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func TestEstimateCost(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	// The costs documented by AlchemyAPI. Both the estimates and the charges of the
+	// emulator come from endpoints.json, so they are checked against these numbers,
+	// not against each other.
+	for _, test := range []struct {
+		action, flavor string
+		options        url.Values
+		want           int
+	}{
+		{"entities", "text", nil, 1},
+		{"entities", "text", url.Values{"sentiment": {"1"}}, 2},
+		{"entities", "text", url.Values{"sentiment": {"0"}}, 1},
+		{"keywords", "url", url.Values{"sentiment": {"1"}, "maxRetrieve": {"5"}}, 2},
+		{"relations", "text", url.Values{"sentiment": {"1"}, "keywords": {"1"}, "entities": {"1"}}, 4},
+		{"combined", "url", nil, 6},
+		{"combined", "text", url.Values{"extract": {"entity,title"}, "sentiment": {"1"}}, 3},
+		{"image_tag", "url", nil, 1},
+	} {
+		got, err := alchemyapi.EstimateCost(test.action, test.flavor, test.options)
+		assert.Equal(nil, err)
+		assert.Equal(test.want, got)
+
+		before := server.Transactions()
+		_, err = client.Analyze(pathOf(t, test.action, test.flavor), withData(test.flavor, test.options))
+		assert.Equal(nil, err)
+		assert.Equal(test.want, server.Transactions()-before)
+	}
+
+	_, err := alchemyapi.EstimateCost("combined", "html", nil)
+	assert.NotNil(err)
+	_, err = alchemyapi.EstimateCost("relations", "text", url.Values{"maxRetrieve": {"500"}})
+	var optErr *alchemyapi.OptionError
+	assert.Equal(true, errors.As(err, &optErr))
+}
+
+func TestDryRun(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client, err := alchemyapi.NewClient(alchemytest.APIKey, alchemyapi.WithBaseURL(server.URL), alchemyapi.WithDryRun())
+	assert.Equal(nil, err)
+
+	_, err = client.GetRelations("text", "Bob broke my heart", url.Values{"entities": {"1"}})
+	assert.Equal(true, errors.Is(err, alchemyapi.ErrDryRun))
+	var dryRun *alchemyapi.DryRunError
+	assert.Equal(true, errors.As(err, &dryRun))
+	assert.Equal("relations", dryRun.Endpoint)
+	assert.Equal("text", dryRun.Flavor)
+	assert.Equal(2, dryRun.Transactions)
+	assert.Equal("dry run: relations for text would cost 2 transactions", err.Error())
+
+	_, err = client.Combined("url", "http://example.com/", alchemyapi.CombinedOptions{Extract: []string{"title", "author"}}.Values())
+	assert.Equal(true, errors.As(err, &dryRun))
+	assert.Equal(2, dryRun.Transactions)
+	_, err = client.GetImageTagsFromReader(nil)
+	assert.Equal(true, errors.As(err, &dryRun))
+	assert.Equal(1, dryRun.Transactions)
+
+	// Invalid calls still fail validation.
+	_, err = client.Relations("text", "Bob", url.Values{"maxRetrieve": {"500"}})
+	assert.Equal(false, errors.Is(err, alchemyapi.ErrDryRun))
+	assert.Equal(0, server.Requests())
+
+	// Free calls are made.
+	_, err = client.KeyInfo()
+	assert.Equal(nil, err)
+	assert.Equal(nil, client.Ping())
+	assert.Equal(2, server.Requests())
+	assert.Equal(0, server.Transactions())
+}
+
+func pathOf(t *testing.T, action string, flavor string) string {
+	endpoints, _ := alchemyapi.DefaultEndpoints()
+	path, ok := endpoints.Path(action, flavor)
+	if !ok {
+		t.Fatalf("no path for %s %s", action, flavor)
+	}
+	return path
+}
+
+// withData returns options with sample data for flavor.
+func withData(flavor string, options url.Values) url.Values {
+	data := map[string]string{"text": "Bob broke my heart", "url": "http://example.com/"}[flavor]
+	values := url.Values{flavor: {data}}
+	for name, v := range options {
+		values[name] = v
+	}
+	return values
+}
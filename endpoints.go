@@ -0,0 +1,275 @@
+package alchemyapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// AlchemyAPI is an endpoint table mapping actions and flavors to endpoint paths,
+// e.g. "entities" and "text" to "/text/TextGetRankedNamedEntities".
+// Actions may also have an EndpointSpec describing the options they accept,
+// their input size limits and their cost, see Spec.
+// Its methods are safe for concurrent use. Endpoints must not be modified
+// directly once the table is used by a client; use Register and Remove instead.
+type AlchemyAPI struct {
+	Endpoints map[string]map[string]string
+	specs     map[string]*EndpointSpec
+	mu        sync.RWMutex
+}
+
+// EndpointSpec describes an action of the endpoint table.
+// In endpoints.json an action is either a map of flavors to paths or an object
+// with the fields of EndpointSpec:
+//
+//	"title": {
+//		"paths": {"url": "/url/URLGetTitle", "html": "/html/HTMLGetTitle"},
+//		"options": {"useMetadata": {"type": "bool", "default": "1"}},
+//		"maxInputSize": {"html": 614400},
+//		"transactions": 1
+//	}
+type EndpointSpec struct {
+	// Paths maps the flavors of the action to their endpoint paths.
+	Paths map[string]string `json:"paths"`
+	// Options are the options the action accepts, besides the flavors, url and
+	// the parameters set by the client. Nil, i.e. no "options" in endpoints.json, means
+	// the options are unknown and not validated; empty means no options are accepted.
+	Options map[string]OptionSpec `json:"options,omitempty"`
+	// MaxInputSize is the largest content accepted per flavor, in bytes.
+	MaxInputSize map[string]int `json:"maxInputSize,omitempty"`
+	// Transactions is the cost of a call without options, 1 unless set.
+	Transactions int `json:"transactions"`
+}
+
+// endpointsJSON is the endpoint table compiled into the package.
+// The endpoint methods in endpoints_gen.go are generated from it.
+//
+//go:generate go run gen.go
+//go:embed endpoints.json
+var endpointsJSON []byte
+
+var (
+	api AlchemyAPI
+	// apiErr is the error parsing endpointsJSON, reported by the constructors and calls.
+	apiErr error
+)
+
+func init() {
+	apiErr = json.Unmarshal(endpointsJSON, &api)
+}
+
+// DefaultEndpoints returns a copy of the endpoint table compiled into the package.
+func DefaultEndpoints() (*AlchemyAPI, error) {
+	return api.Clone(), apiErr
+}
+
+// LoadEndpoints reads an endpoint table in the format of endpoints.json from r.
+func LoadEndpoints(r io.Reader) (*AlchemyAPI, error) {
+	endpoints := &AlchemyAPI{}
+	if err := json.NewDecoder(r).Decode(endpoints); err != nil {
+		return nil, fmt.Errorf("reading endpoints: %v", err)
+	}
+	return endpoints, nil
+}
+
+// LoadEndpointsFile reads an endpoint table in the format of endpoints.json from the named file.
+func LoadEndpointsFile(name string) (*AlchemyAPI, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadEndpoints(f)
+}
+
+// UnmarshalJSON reads a table in the format of endpoints.json, replacing the content of api.
+func (api *AlchemyAPI) UnmarshalJSON(data []byte) error {
+	var actions map[string]json.RawMessage
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return err
+	}
+	endpoints := make(map[string]map[string]string, len(actions))
+	specs := map[string]*EndpointSpec{}
+	for action, raw := range actions {
+		var paths map[string]string
+		if json.Unmarshal(raw, &paths) == nil {
+			endpoints[action] = paths
+			continue
+		}
+		spec := &EndpointSpec{Transactions: 1}
+		if err := json.Unmarshal(raw, spec); err != nil {
+			return fmt.Errorf("%s: %v", action, err)
+		}
+		if len(spec.Paths) == 0 {
+			return fmt.Errorf("%s: no paths", action)
+		}
+		for name, option := range spec.Options {
+			if err := option.valid(); err != nil {
+				return fmt.Errorf("%s: option %s: %v", action, name, err)
+			}
+		}
+		endpoints[action] = spec.Paths
+		spec.Paths = nil
+		specs[action] = spec
+	}
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.Endpoints = endpoints
+	api.specs = specs
+	return nil
+}
+
+// Clone returns a deep copy of the table.
+func (api *AlchemyAPI) Clone() *AlchemyAPI {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+	clone := &AlchemyAPI{Endpoints: make(map[string]map[string]string, len(api.Endpoints))}
+	for action, flavors := range api.Endpoints {
+		clone.Endpoints[action] = make(map[string]string, len(flavors))
+		for flavor, path := range flavors {
+			clone.Endpoints[action][flavor] = path
+		}
+	}
+	// Specs are never modified once loaded, clones share them.
+	clone.specs = make(map[string]*EndpointSpec, len(api.specs))
+	for action, spec := range api.specs {
+		clone.specs[action] = spec
+	}
+	return clone
+}
+
+// Path returns the endpoint path of the action for flavor.
+func (api *AlchemyAPI) Path(action string, flavor string) (string, bool) {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+	path, ok := api.Endpoints[action][flavor]
+	return path, ok
+}
+
+// Actions returns the sorted actions of the table.
+func (api *AlchemyAPI) Actions() []string {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+	actions := make([]string, 0, len(api.Endpoints))
+	for action := range api.Endpoints {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// Spec returns a copy of the description of the action, for tools such as
+// help screens or gateway docs. Actions without metadata in the table,
+// such as actions only added with Register, have only Paths and a Transactions of 1.
+func (api *AlchemyAPI) Spec(action string) (EndpointSpec, bool) {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+	flavors, ok := api.Endpoints[action]
+	if !ok {
+		return EndpointSpec{}, false
+	}
+	spec := EndpointSpec{Transactions: 1}
+	if s := api.specs[action]; s != nil {
+		spec = s.copy()
+	}
+	spec.Paths = make(map[string]string, len(flavors))
+	for flavor, path := range flavors {
+		spec.Paths[flavor] = path
+	}
+	return spec, true
+}
+
+// flavors returns the sorted flavors of the action.
+func (api *AlchemyAPI) flavors(action string) []string {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+	flavors := make([]string, 0, len(api.Endpoints[action]))
+	for flavor := range api.Endpoints[action] {
+		flavors = append(flavors, flavor)
+	}
+	sort.Strings(flavors)
+	return flavors
+}
+
+// Register sets the endpoint path of the action for flavor, adding or replacing it.
+func (api *AlchemyAPI) Register(action string, flavor string, path string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if api.Endpoints == nil {
+		api.Endpoints = map[string]map[string]string{}
+	}
+	if api.Endpoints[action] == nil {
+		api.Endpoints[action] = map[string]string{}
+	}
+	api.Endpoints[action][flavor] = path
+}
+
+// RegisterSpec registers the paths of spec like Register and replaces the
+// options, input size limits and cost of the action with those of spec.
+// spec.Transactions is used as is.
+func (api *AlchemyAPI) RegisterSpec(action string, spec EndpointSpec) error {
+	for name, option := range spec.Options {
+		if err := option.valid(); err != nil {
+			return fmt.Errorf("%s: option %s: %v", action, name, err)
+		}
+	}
+	for flavor, path := range spec.Paths {
+		api.Register(action, flavor, path)
+	}
+	spec = spec.copy()
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if api.specs == nil {
+		api.specs = map[string]*EndpointSpec{}
+	}
+	api.specs[action] = &spec
+	return nil
+}
+
+// copy returns a deep copy of s without its paths.
+func (s *EndpointSpec) copy() EndpointSpec {
+	c := EndpointSpec{Transactions: s.Transactions}
+	if s.Options != nil {
+		c.Options = make(map[string]OptionSpec, len(s.Options))
+		for name, option := range s.Options {
+			option.Values = append([]string(nil), option.Values...)
+			c.Options[name] = option
+		}
+	}
+	if s.MaxInputSize != nil {
+		c.MaxInputSize = make(map[string]int, len(s.MaxInputSize))
+		for flavor, size := range s.MaxInputSize {
+			c.MaxInputSize[flavor] = size
+		}
+	}
+	return c
+}
+
+// Remove removes the endpoint of the action for flavor, making that call unavailable.
+func (api *AlchemyAPI) Remove(action string, flavor string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	delete(api.Endpoints[action], flavor)
+	if len(api.Endpoints[action]) == 0 {
+		delete(api.Endpoints, action)
+		delete(api.specs, action)
+	}
+}
+
+// endpointOf returns the action and flavor served by the endpoint path ep, if any.
+func (api *AlchemyAPI) endpointOf(ep string) (action string, flavor string) {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+	for action, flavors := range api.Endpoints {
+		for flavor, path := range flavors {
+			if path == ep {
+				return action, flavor
+			}
+		}
+	}
+	return "", ""
+}
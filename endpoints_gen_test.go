@@ -0,0 +1,38 @@
+package alchemyapi
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ronna-s/alchemyapi_go/internal/endpointgen"
+)
+
+func TestGeneratedEndpoints(t *testing.T) {
+	want, err := endpointgen.Generate(endpointsJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("endpoints_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("endpoints_gen.go is out of date with endpoints.json, run go generate")
+	}
+}
+
+// Every endpoint method of *Client must be part of Analyzer: the generated ones
+// through EndpointAnalyzer, the hand-written ones, such as KeyInfo, directly.
+func TestAnalyzerMethods(t *testing.T) {
+	notEndpoints := map[string]bool{"Endpoints": true, "SetRetryPolicy": true, "SetMaxResponseSize": true, "LimiterStats": true, "Batch": true, "BatchItems": true}
+	analyzer := reflect.TypeOf((*Analyzer)(nil)).Elem()
+	client := reflect.TypeOf((*Client)(nil))
+	for i := 0; i < client.NumMethod(); i++ {
+		name := client.Method(i).Name
+		if _, ok := analyzer.MethodByName(name); !ok && !notEndpoints[name] {
+			t.Errorf("Analyzer is missing %s", name)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package alchemyapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDefaultEndpoints(t *testing.T) {
+	assert := NewAssert(t)
+	assert.Equal(nil, apiErr)
+	assert.Equal("/text/TextGetRankedNamedEntities", api.Endpoints["entities"]["text"])
+	assert.Equal("/image/ImageGetRankedImageKeywords", api.Endpoints["image_tag"]["image"])
+}
+
+func TestNewWithEndpoints(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK","url":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	a, err := NewWithEndpoints("key", server.URL, server.Client(), strings.NewReader(`{"entities":{"text":"/proxy/entities"}}`))
+	assert.Equal(nil, err)
+	response, err := a.GetEntities("text", "Bob")
+	assert.Equal(nil, err)
+	assert.Equal("/proxy/entities", response.URL)
+	response, err = a.GetEntities("url", "http://example.com")
+	assert.Equal(nil, err)
+	assert.Equal("/url/URLGetRankedNamedEntities", response.URL)
+	path, _ := New("key", server.URL, server.Client()).Endpoints().Path("entities", "text")
+	assert.Equal("/text/TextGetRankedNamedEntities", path)
+
+	_, err = NewWithEndpoints("key", server.URL, server.Client(), strings.NewReader(`{"entities":`))
+	assert.NotNil(err)
+
+	name := filepath.Join(t.TempDir(), "endpoints.json")
+	assert.Equal(nil, os.WriteFile(name, []byte(`{"keywords":{"text":"/proxy/keywords"}}`), 0644))
+	endpoints, err := LoadEndpointsFile(name)
+	assert.Equal(nil, err)
+	assert.Equal("/proxy/keywords", endpoints.Endpoints["keywords"]["text"])
+	_, err = LoadEndpointsFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NotNil(err)
+}
+
+func TestEndpointRegistry(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK","url":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+	legacy := New("key", server.URL, server.Client())
+	proxy := New("key", server.URL, server.Client())
+	proxy.Endpoints().Register("entities", "text", "/v2/entities")
+	proxy.Endpoints().Register("entities", "pdf", "/v2/entities/pdf")
+	proxy.Endpoints().Remove("keywords", "text")
+
+	response, err := proxy.GetEntities("text", "Bob")
+	assert.Equal(nil, err)
+	assert.Equal("/v2/entities", response.URL)
+	response, err = proxy.GetEntities("pdf", "%PDF")
+	assert.Equal(nil, err)
+	assert.Equal("/v2/entities/pdf", response.URL)
+	_, err = proxy.Keywords("text", "Bob")
+	assert.NotNil(err)
+	response, err = legacy.GetEntities("text", "Bob")
+	assert.Equal(nil, err)
+	assert.Equal("/text/TextGetRankedNamedEntities", response.URL)
+	_, err = legacy.Keywords("text", "Bob")
+	assert.Equal(nil, err)
+
+	defaults, err := DefaultEndpoints()
+	assert.Equal(nil, err)
+	path, ok := defaults.Path("entities", "text")
+	assert.Equal(true, ok)
+	assert.Equal("/text/TextGetRankedNamedEntities", path)
+	proxy.Endpoints().Remove("taxonomy", "url")
+	proxy.Endpoints().Remove("taxonomy", "text")
+	proxy.Endpoints().Remove("taxonomy", "html")
+	_, ok = proxy.Endpoints().Endpoints["taxonomy"]
+	assert.Equal(false, ok)
+
+	// Registering while calls are in flight is safe.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			proxy.Entities("text", "Bob")
+		}()
+	}
+	proxy.Endpoints().Register("entities", "text", "/v3/entities")
+	wg.Wait()
+}
+
+func TestEndpointSpecs(t *testing.T) {
+	assert := NewAssert(t)
+	spec, ok := api.Spec("relations")
+	assert.Equal(true, ok)
+	assert.Equal("/text/TextGetRelations", spec.Paths["text"])
+	assert.Equal(100, spec.Options["maxRetrieve"].Max)
+	assert.Equal("50", spec.Options["maxRetrieve"].Default)
+	assert.Equal(1, spec.Options["entities"].Transactions)
+	assert.Equal(51200, spec.MaxInputSize["text"])
+	assert.Equal(1, spec.Transactions)
+	spec, _ = api.Spec("combined")
+	assert.Equal(0, spec.Transactions)
+	assert.Equal(OptionList, spec.Options["extract"].Type)
+	spec.Options["extract"].Values[0] = "changed"
+	spec, _ = api.Spec("combined")
+	assert.Equal("page-image", spec.Options["extract"].Values[0])
+	_, ok = api.Spec("missing")
+	assert.Equal(false, ok)
+	assert.Equal(len(api.Endpoints), len(api.Actions()))
+	assert.Equal("author", api.Actions()[0])
+
+	// Old and new formats can be mixed; old entries are not validated.
+	endpoints, err := LoadEndpoints(strings.NewReader(`{
+		"entities": {"text": "/proxy/entities"},
+		"keywords": {"paths": {"text": "/proxy/keywords"}, "options": {"strict": {"type": "bool"}}, "maxInputSize": {"text": 10}}
+	}`))
+	assert.Equal(nil, err)
+	spec, _ = endpoints.Spec("entities")
+	assert.Equal(true, spec.Options == nil)
+	assert.Equal(1, spec.Transactions)
+	assert.Equal(nil, endpoints.validate("entities", "text", 1<<20, url.Values{"anything": {"1"}}))
+	spec, _ = endpoints.Spec("keywords")
+	assert.Equal("/proxy/keywords", spec.Paths["text"])
+	assert.Equal(1, spec.Transactions)
+	assert.Equal(nil, endpoints.validate("keywords", "text", 10, url.Values{"strict": {"1"}}))
+	var optErr *OptionError
+	assert.Equal(true, errors.As(endpoints.validate("keywords", "text", 10, url.Values{"sentiment": {"1"}}), &optErr))
+	assert.Equal(true, errors.Is(endpoints.validate("keywords", "text", 11, nil), ErrContentExceedsSizeLimit))
+
+	// A spec without options does not validate them, one with empty options accepts none.
+	endpoints, err = LoadEndpoints(strings.NewReader(`{
+		"entities": {"paths": {"text": "/proxy/entities"}, "transactions": 2},
+		"title": {"paths": {"url": "/proxy/title"}, "options": {}}
+	}`))
+	assert.Equal(nil, err)
+	spec, _ = endpoints.Spec("entities")
+	assert.Equal(true, spec.Options == nil)
+	assert.Equal(2, spec.Transactions)
+	assert.Equal(nil, endpoints.validate("entities", "text", 1, url.Values{"maxRetrieve": {"5"}}))
+	spec, _ = endpoints.Spec("title")
+	assert.Equal(true, spec.Options != nil && len(spec.Options) == 0)
+	assert.Equal(true, errors.As(endpoints.validate("title", "url", 1, url.Values{"useMetadata": {"1"}}), &optErr))
+	assert.Equal(nil, endpoints.validate("title", "url", 1, url.Values{"url": {"http://example.com/"}}))
+
+	for _, table := range []string{
+		`{"entities": {"text": 1}}`,
+		`{"entities": {"paths": {}}}`,
+		`{"entities": {"paths": {"text": "/e"}, "options": {"x": {"type": "float"}}}}`,
+		`{"entities": {"paths": {"text": "/e"}, "options": {"x": {"type": "enum"}}}}`,
+	} {
+		_, err = LoadEndpoints(strings.NewReader(table))
+		assert.NotNil(err)
+	}
+}
+
+func TestClientEndpointSpecs(t *testing.T) {
+	assert := NewAssert(t)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"status":"OK","url":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+	a, err := NewClient("key", WithBaseURL(server.URL), WithEndpoints(strings.NewReader(`{
+		"entities": {"text": "/proxy/entities"},
+		"keywords": {"paths": {"text": "/proxy/keywords"}, "options": {"strict": {"type": "bool"}}}
+	}`)))
+	assert.Equal(nil, err)
+
+	_, err = a.Entities("text", strings.Repeat("x", 51201))
+	assert.Equal(true, errors.Is(err, ErrContentExceedsSizeLimit))
+	_, err = a.ImageTags("image", strings.Repeat("x", 1<<20+1))
+	assert.Equal(true, errors.Is(err, ErrContentExceedsSizeLimit))
+	_, err = a.GetImageTagsFromReader(strings.NewReader(strings.Repeat("x", 2<<20)))
+	assert.Equal(true, errors.Is(err, ErrContentExceedsSizeLimit))
+	assert.Equal("image_tag: image exceeds the limit of 1048576 bytes: content-exceeds-size-limit", err.Error())
+	_, err = a.Entities("text", "Bob", url.Values{"maxRetrieve": {"0"}})
+	var optErr *OptionError
+	assert.Equal(true, errors.As(err, &optErr))
+	_, err = a.Keywords("text", "Bob", url.Values{"maxRetrieve": {"5"}})
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal(int32(0), atomic.LoadInt32(&calls))
+	response, err := a.GetKeywords("text", "Bob", url.Values{"strict": {"1"}})
+	assert.Equal(nil, err)
+	assert.Equal("/proxy/keywords", response.URL)
+
+	assert.Equal(nil, a.Endpoints().RegisterSpec("pdf", EndpointSpec{
+		Paths:   map[string]string{"url": "/url/URLGetPDF"},
+		Options: map[string]OptionSpec{"pages": {Type: OptionInt, Min: 1}},
+	}))
+	spec, ok := a.Endpoints().Spec("pdf")
+	assert.Equal(true, ok)
+	assert.Equal(OptionInt, spec.Options["pages"].Type)
+	assert.NotNil(a.Endpoints().RegisterSpec("pdf", EndpointSpec{Options: map[string]OptionSpec{"pages": {Type: "pages"}}}))
+}
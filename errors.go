@@ -0,0 +1,108 @@
+package alchemyapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ErrorKind classifies the statusInfo of an AlchemyAPI error response.
+	// The Err* kinds are sentinel errors to use with errors.Is; an *APIError
+	// unwraps to its Kind. Status infos without a predefined kind get their
+	// own ErrorKind, e.g. ErrorKind("invalid-html").
+	ErrorKind string
+
+	// APIError is returned when AlchemyAPI answers with an ERROR status.
+	APIError struct {
+		// Endpoint and Flavor identify the call, e.g. "entities" and "text".
+		// They are empty for Analyze calls to paths not in the endpoint table.
+		Endpoint string
+		Flavor   string
+		// Path is the endpoint path the request was sent to.
+		Path string
+		// StatusCode is the HTTP status code of the response.
+		StatusCode int
+		// StatusInfo is the statusInfo of the response as sent, e.g. "cannot-retrieve:http-404".
+		StatusInfo string
+		// Kind is the part of StatusInfo before the first colon.
+		Kind ErrorKind
+		// SubCode is the HTTP status of a cannot-retrieve:http-NNN error, 0 otherwise.
+		SubCode int
+	}
+
+	// ResponseError is returned when the response is not an AlchemyAPI JSON
+	// response, e.g. the HTML error page of a proxy or gateway in front of it.
+	ResponseError struct {
+		Path        string
+		StatusCode  int
+		Status      string
+		ContentType string
+		// Reason tells what was unexpected about the response.
+		Reason string
+		// Snippet is the beginning of the response body, truncated to maxSnippet bytes.
+		Snippet string
+	}
+)
+
+// maxSnippet is the length of the response body kept in a ResponseError.
+const maxSnippet = 256
+
+const (
+	ErrInvalidAPIKey                 ErrorKind = "invalid-api-key"
+	ErrDailyTransactionLimitExceeded ErrorKind = "daily-transaction-limit-exceeded"
+	ErrContentExceedsSizeLimit       ErrorKind = "content-exceeds-size-limit"
+	ErrUnsupportedTextLanguage       ErrorKind = "unsupported-text-language"
+	ErrCannotRetrieve                ErrorKind = "cannot-retrieve"
+	ErrCannotLocate                  ErrorKind = "cannot-locate"
+	ErrInvalidURL                    ErrorKind = "invalid-url"
+	ErrPageIsNotHTML                 ErrorKind = "page-is-not-html"
+	ErrContentIsEmpty                ErrorKind = "content-is-empty"
+)
+
+func (k ErrorKind) Error() string {
+	return string(k)
+}
+
+// newAPIError builds the error for an ERROR response to c.
+func newAPIError(c *call, statusCode int, statusInfo string) *APIError {
+	e := &APIError{Endpoint: c.action, Flavor: c.flavor, Path: c.path, StatusCode: statusCode, StatusInfo: statusInfo}
+	kind, sub := statusInfo, ""
+	if i := strings.Index(statusInfo, ":"); i >= 0 {
+		kind, sub = statusInfo[:i], statusInfo[i+1:]
+	}
+	e.Kind = ErrorKind(kind)
+	if strings.HasPrefix(sub, "http-") {
+		e.SubCode, _ = strconv.Atoi(sub[len("http-"):])
+	}
+	return e
+}
+
+// Error returns the statusInfo, as the client did before APIError was introduced.
+func (e *APIError) Error() string {
+	return e.StatusInfo
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+func (e *ResponseError) Error() string {
+	msg := fmt.Sprintf("%s: %s (%s", e.Path, e.Reason, e.Status)
+	if e.ContentType != "" {
+		msg += ", " + e.ContentType
+	}
+	msg += ")"
+	if e.Snippet != "" {
+		msg += ": " + strconv.Quote(e.Snippet)
+	}
+	return msg
+}
+
+// snippet returns the beginning of body for a ResponseError.
+func snippet(body []byte) string {
+	if len(body) <= maxSnippet {
+		return string(body)
+	}
+	return string(body[:maxSnippet]) + "..."
+}
@@ -0,0 +1,95 @@
+package alchemyapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ERROR","statusInfo":"` + r.FormValue("text") + `"}`))
+	}))
+	defer server.Close()
+	a := New("key", server.URL, server.Client())
+
+	_, err := a.Entities("text", "invalid-api-key")
+	assert.Equal(true, errors.Is(err, ErrInvalidAPIKey))
+	assert.Equal(false, errors.Is(err, ErrCannotRetrieve))
+	var apiErr *APIError
+	assert.Equal(true, errors.As(err, &apiErr))
+	assert.Equal("entities", apiErr.Endpoint)
+	assert.Equal("text", apiErr.Flavor)
+	assert.Equal("/text/TextGetRankedNamedEntities", apiErr.Path)
+	assert.Equal(http.StatusOK, apiErr.StatusCode)
+	assert.Equal("invalid-api-key", err.Error())
+
+	_, err = a.GetKeywords("text", "cannot-retrieve:http-404")
+	assert.Equal(true, errors.Is(err, ErrCannotRetrieve))
+	assert.Equal(true, errors.As(err, &apiErr))
+	assert.Equal(404, apiErr.SubCode)
+	assert.Equal("cannot-retrieve:http-404", apiErr.StatusInfo)
+
+	_, err = a.Analyze("/text/TextGetLanguage", map[string][]string{"text": {"invalid-html"}})
+	assert.Equal(true, errors.Is(err, ErrorKind("invalid-html")))
+	assert.Equal(true, errors.As(err, &apiErr))
+	assert.Equal("language", apiErr.Endpoint)
+	assert.Equal(0, apiErr.SubCode)
+}
+
+func TestResponseError(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("text") {
+		case "html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><body>" + strings.Repeat("Service Temporarily Unavailable ", 20) + "</body></html>"))
+		case "404":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("no such endpoint"))
+		case "403":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"status":"ERROR","statusInfo":"invalid-api-key"}`))
+		case "large":
+			w.Write([]byte(`{"status":"OK","text":"` + strings.Repeat("a", 100) + `"}`))
+		default:
+			w.Write([]byte("OK"))
+		}
+	}))
+	defer server.Close()
+	a := New("key", server.URL, server.Client())
+	var respErr *ResponseError
+
+	_, err := a.Language("text", "html")
+	assert.Equal(true, errors.As(err, &respErr))
+	assert.Equal("unexpected content type", respErr.Reason)
+	assert.Equal("text/html", respErr.ContentType)
+	assert.Equal(maxSnippet+len("..."), len(respErr.Snippet))
+	assert.Equal(true, strings.Contains(err.Error(), "Service Temporarily Unavailable"))
+
+	_, err = a.Language("text", "404")
+	assert.Equal(true, errors.As(err, &respErr))
+	assert.Equal("unexpected status", respErr.Reason)
+	assert.Equal(http.StatusNotFound, respErr.StatusCode)
+	assert.Equal("no such endpoint", respErr.Snippet)
+
+	_, err = a.Language("text", "403")
+	var apiErr *APIError
+	assert.Equal(true, errors.As(err, &apiErr))
+	assert.Equal(http.StatusForbidden, apiErr.StatusCode)
+
+	_, err = a.Language("text", "OK")
+	assert.Equal(true, errors.As(err, &respErr))
+	assert.Equal("invalid JSON response", respErr.Reason)
+
+	_, err = a.Language("text", "large")
+	assert.Equal(nil, err)
+	a.SetMaxResponseSize(64)
+	_, err = a.Language("text", "large")
+	assert.Equal(true, errors.As(err, &respErr))
+	assert.Equal("response larger than 64 bytes", respErr.Reason)
+}
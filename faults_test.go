@@ -0,0 +1,86 @@
+package alchemyapi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func TestFaults(t *testing.T) {
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	for _, test := range []struct {
+		name  string
+		fault alchemytest.Fault
+		check func(err error) bool
+	}{
+		{"dropped connection", alchemytest.Fault{Kind: alchemytest.FaultDrop}, alchemyapi.Retryable},
+		{"503", alchemytest.Fault{Kind: alchemytest.FaultStatus}, alchemyapi.Retryable},
+		{"500", alchemytest.Fault{Kind: alchemytest.FaultStatus, StatusCode: 500}, alchemyapi.Retryable},
+		{"truncated body", alchemytest.Fault{Kind: alchemytest.FaultTruncate}, alchemyapi.Retryable},
+		{"non-JSON body", alchemytest.Fault{Kind: alchemytest.FaultNonJSON}, func(err error) bool {
+			var respErr *alchemyapi.ResponseError
+			return errors.As(err, &respErr) && respErr.Reason == "invalid JSON response"
+		}},
+		{"HTML error page", alchemytest.Fault{Kind: alchemytest.FaultHTML}, alchemyapi.Retryable},
+		{"daily limit", alchemytest.Fault{Kind: alchemytest.FaultAPIError}, func(err error) bool {
+			return errors.Is(err, alchemyapi.ErrDailyTransactionLimitExceeded)
+		}},
+	} {
+		server.ClearFaults()
+		server.InjectFault(test.fault)
+		response, err := client.GetEntities("text", "Bob broke my heart")
+		if !test.check(err) {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if err == nil && len(response.Entities) == 0 {
+			t.Errorf("%s: got an empty response without an error", test.name)
+		}
+	}
+	server.ClearFaults()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	server.InjectFault(alchemytest.Fault{Kind: alchemytest.FaultLatency, Latency: time.Second})
+	if _, err := client.EntitiesContext(ctx, "text", "Bob broke my heart"); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	server.ClearFaults()
+}
+
+func TestFaultsEveryNth(t *testing.T) {
+	server := alchemytest.NewServer()
+	defer server.Close()
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+	server.InjectFault(alchemytest.Fault{Kind: alchemytest.FaultAPIError, Every: 3, Action: "keywords"})
+
+	var failed []int
+	for i := 1; i <= 6; i++ {
+		if _, err := client.Keywords("text", "Bob broke my heart"); err != nil {
+			failed = append(failed, i)
+		}
+		if _, err := client.Entities("text", "Bob broke my heart"); err != nil {
+			t.Errorf("entities call %d failed: %v", i, err)
+		}
+	}
+	if len(failed) != 2 || failed[0] != 3 || failed[1] != 6 {
+		t.Errorf("calls %v failed, want 3 and 6", failed)
+	}
+
+	// A retry policy gets through faults that are not injected on every call.
+	server.ClearFaults()
+	server.InjectFault(alchemytest.Fault{Kind: alchemytest.FaultDrop, Every: 2})
+	server.InjectFault(alchemytest.Fault{Kind: alchemytest.FaultHTML, Every: 2})
+	client.SetRetryPolicy(&alchemyapi.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	for i := 0; i < 4; i++ {
+		if _, err := client.Entities("text", "Bob broke my heart"); err != nil {
+			t.Errorf("call %d failed despite retries: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+
+// gen.go writes endpoints_gen.go from endpoints.json. It is run by go generate.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/ronna-s/alchemyapi_go/internal/endpointgen"
+)
+
+func main() {
+	endpoints, err := os.ReadFile("endpoints.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := endpointgen.Generate(endpoints)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("endpoints_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,378 @@
+// Package endpointgen generates the endpoint methods, typed responses methods and
+// option structs of package alchemyapi from its endpoint table, endpoints.json.
+// It is run by go generate, see gen.go in the package directory.
+//
+// Actions with a "go" object are generated:
+//
+//	"go": {"method": "Entities", "response": "EntitiesResponse", "doc": ["Extracts the entities ..."]}
+//
+// Required options become arguments of the methods, the other options fields of the option struct.
+// The field of an enum option is a string, or the string type named by its "goType".
+package endpointgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+type (
+	action struct {
+		name    string
+		flavors []string
+		options []option
+		method  string
+		resp    string
+		doc     []string
+	}
+
+	option struct {
+		name         string
+		Type         string   `json:"type"`
+		Values       []string `json:"values"`
+		Min          int      `json:"min"`
+		Max          int      `json:"max"`
+		Required     bool     `json:"required"`
+		Default      string   `json:"default"`
+		Transactions int      `json:"transactions"`
+		Doc          string   `json:"doc"`
+		// GoType is the string type of an enum field, e.g. "ExtractMode", string if empty.
+		GoType string `json:"goType"`
+	}
+
+	spec struct {
+		Paths   json.RawMessage `json:"paths"`
+		Options json.RawMessage `json:"options"`
+		Go      *struct {
+			Method   string   `json:"method"`
+			Response string   `json:"response"`
+			Doc      []string `json:"doc"`
+		} `json:"go"`
+	}
+)
+
+// dataDocs describe the data argument for every flavor.
+var dataDocs = map[string]string{
+	"text":  "the text",
+	"url":   "the url",
+	"html":  "html code",
+	"image": "the raw image bytes",
+}
+
+// Generate returns the formatted Go source of endpoints_gen.go for the endpoint table in endpointsJSON.
+func Generate(endpointsJSON []byte) ([]byte, error) {
+	actions, err := parse(endpointsJSON)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go from endpoints.json; DO NOT EDIT.\n\n")
+	b.WriteString("package alchemyapi\n\nimport (\n\t\"context\"\n\t\"net/url\"\n)\n\n")
+	b.WriteString("// EndpointAnalyzer holds the endpoint methods of Analyzer, those of the calls of endpoints.json.\n")
+	b.WriteString("type EndpointAnalyzer interface {\n")
+	for _, a := range actions {
+		a.writeInterface(&b)
+	}
+	b.WriteString("}\n")
+	for _, a := range actions {
+		a.writeMethods(&b)
+	}
+	for _, a := range actions {
+		a.writeTypedMethods(&b)
+	}
+	for _, a := range actions {
+		a.writeOptions(&b)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+// parse returns the generated actions of the table, in the order of the table.
+func parse(endpointsJSON []byte) ([]action, error) {
+	var actions []action
+	err := eachKey(endpointsJSON, func(name string, raw json.RawMessage) error {
+		var s spec
+		if json.Unmarshal(raw, &s) != nil || s.Go == nil {
+			// Paths only, or not generated.
+			return nil
+		}
+		if s.Go.Method == "" || s.Go.Response == "" {
+			return fmt.Errorf("%s: go needs a method and a response", name)
+		}
+		a := action{name: name, method: s.Go.Method, resp: s.Go.Response, doc: s.Go.Doc}
+		err := eachKey(s.Paths, func(flavor string, _ json.RawMessage) error {
+			a.flavors = append(a.flavors, flavor)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: paths: %v", name, err)
+		}
+		if len(s.Options) != 0 {
+			err = eachKey(s.Options, func(name string, raw json.RawMessage) error {
+				o := option{name: name}
+				if err := json.Unmarshal(raw, &o); err != nil {
+					return err
+				}
+				a.options = append(a.options, o)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("%s: options: %v", name, err)
+			}
+		}
+		actions = append(actions, a)
+		return nil
+	})
+	return actions, err
+}
+
+// eachKey calls f with the keys and values of the JSON object in data, in order.
+func eachKey(data []byte, f func(key string, value json.RawMessage) error) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	if t, err := d.Token(); err != nil || t != json.Delim('{') {
+		return fmt.Errorf("expected an object")
+	}
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := d.Decode(&value); err != nil {
+			return err
+		}
+		if err := f(t.(string), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// required returns the required options, which are arguments of the methods.
+func (a action) required() []option {
+	var required []option
+	for _, o := range a.options {
+		if o.Required {
+			required = append(required, o)
+		}
+	}
+	return required
+}
+
+// params returns the parameters and arguments of the methods after ctx.
+func (a action) params() (params string, args string) {
+	params, args = "flavor string, data string", "flavor, data"
+	for _, o := range a.required() {
+		params += ", " + o.name + " string"
+		args += ", " + o.name
+	}
+	return params + ", options ...url.Values", args + ", options..."
+}
+
+// optionsArg returns the options passed to analyze.
+func (a action) optionsArg() string {
+	required := a.required()
+	if len(required) == 0 {
+		return "options..."
+	}
+	pairs := make([]string, len(required))
+	for i, o := range required {
+		pairs[i] = fmt.Sprintf("%q, %s", o.name, o.name)
+	}
+	return "withRequired(options, " + strings.Join(pairs, ", ") + ")"
+}
+
+// writeInterface writes the methods of the action in EndpointAnalyzer.
+func (a action) writeInterface(b *bytes.Buffer) {
+	params, _ := a.params()
+	fmt.Fprintf(b, "\t%s(%s) (Result, error)\n", a.method, params)
+	fmt.Fprintf(b, "\t%sContext(ctx context.Context, %s) (Result, error)\n", a.method, params)
+	fmt.Fprintf(b, "\tGet%s(%s) (*%s, error)\n", a.method, params, a.resp)
+	fmt.Fprintf(b, "\tGet%sContext(ctx context.Context, %s) (*%s, error)\n", a.method, params, a.resp)
+}
+
+func (a action) writeMethods(b *bytes.Buffer) {
+	params, args := a.params()
+	b.WriteString("\n")
+	for _, line := range a.doc {
+		fmt.Fprintf(b, "// %s\n", line)
+	}
+	b.WriteString("// INPUT:\n")
+	fmt.Fprintf(b, "// flavor -> which version of the call, i.e. %s.\n", orList(a.flavors, func(f string) string { return f }))
+	if len(a.flavors) == 1 {
+		fmt.Fprintf(b, "// data -> the data to analyze, %s.\n", dataDocs[a.flavors[0]])
+	} else {
+		fmt.Fprintf(b, "// data -> the data to analyze, either %s.\n", orList(a.flavors, func(f string) string { return dataDocs[f] }))
+	}
+	for _, o := range a.required() {
+		fmt.Fprintf(b, "// %s -> %s.\n", o.name, o.Doc)
+	}
+	b.WriteString("// options -> various parameters that can be used to adjust how the API works, see below for more info on the available options.\n")
+	b.WriteString("// Available Options:\n")
+	n := 0
+	for _, o := range a.options {
+		if !o.Required {
+			fmt.Fprintf(b, "// %s\n", o.describe())
+			n++
+		}
+	}
+	if n == 0 {
+		b.WriteString("// none\n")
+	}
+	b.WriteString("// It returns the response as an interface\n")
+	fmt.Fprintf(b, "func (a *Client) %s(%s) (Result, error) {\n", a.method, params)
+	fmt.Fprintf(b, "\treturn a.%sContext(context.Background(), %s)\n}\n\n", a.method, args)
+	fmt.Fprintf(b, "// %sContext is like %s but uses ctx for the request.\n", a.method, a.method)
+	fmt.Fprintf(b, "func (a *Client) %sContext(ctx context.Context, %s) (Result, error) {\n", a.method, params)
+	fmt.Fprintf(b, "\treturn a.analyze(ctx, %q, flavor, data, %s)\n}\n", a.name, a.optionsArg())
+}
+
+func (a action) writeTypedMethods(b *bytes.Buffer) {
+	params, args := a.params()
+	fmt.Fprintf(b, "\n// Get%s is like %s but returns a *%s.\n", a.method, a.method, a.resp)
+	fmt.Fprintf(b, "func (a *Client) Get%s(%s) (*%s, error) {\n", a.method, params, a.resp)
+	fmt.Fprintf(b, "\treturn a.Get%sContext(context.Background(), %s)\n}\n\n", a.method, args)
+	fmt.Fprintf(b, "// Get%sContext is like Get%s but uses ctx for the request.\n", a.method, a.method)
+	fmt.Fprintf(b, "func (a *Client) Get%sContext(ctx context.Context, %s) (*%s, error) {\n", a.method, params, a.resp)
+	fmt.Fprintf(b, "\tv := &%s{}\n", a.resp)
+	fmt.Fprintf(b, "\treturn v, a.analyzeInto(ctx, v, %q, flavor, data, %s)\n}\n", a.name, a.optionsArg())
+}
+
+// writeOptions writes the option struct of the action, if it has options besides the required ones.
+func (a action) writeOptions(b *bytes.Buffer) {
+	var options []option
+	for _, o := range a.options {
+		if !o.Required {
+			options = append(options, o)
+		}
+	}
+	if len(options) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n// %sOptions are the options of %s. Zero fields are not sent.\n", a.method, a.method)
+	fmt.Fprintf(b, "type %sOptions struct {\n", a.method)
+	for _, o := range options {
+		fmt.Fprintf(b, "\t// %s\n\t%s %s\n", o.describe(), o.field(), o.goType())
+	}
+	b.WriteString("}\n\n")
+	fmt.Fprintf(b, "// Values returns the options to pass to %s.\n", a.method)
+	fmt.Fprintf(b, "func (o %sOptions) Values() url.Values {\n\tv := optionValues{}\n", a.method)
+	for _, o := range options {
+		fmt.Fprintf(b, "\tv.%s(%q, %s)\n", o.setter(), o.name, o.value())
+	}
+	b.WriteString("\treturn url.Values(v)\n}\n\n")
+	b.WriteString("// Validate checks the options against the default endpoint table.\n")
+	b.WriteString("// Use ValidateFor for clients with their own endpoint table.\n")
+	fmt.Fprintf(b, "func (o %sOptions) Validate() error {\n\treturn ValidateOptions(%q, o.Values())\n}\n\n", a.method, a.name)
+	b.WriteString("// ValidateFor checks the options against endpoints, e.g. client.Endpoints(),\n")
+	b.WriteString("// like a client with that table does before sending them.\n")
+	fmt.Fprintf(b, "func (o %sOptions) ValidateFor(endpoints *AlchemyAPI) error {\n\treturn endpoints.ValidateOptions(%q, o.Values())\n}\n", a.method, a.name)
+}
+
+// describe documents the option in the style of the method docs, e.g.
+// "quotations -> extract quotations by entities. 0: disabled (default), 1: enabled".
+func (o option) describe() string {
+	s := o.name + " -> " + o.Doc
+	switch o.Type {
+	case "bool":
+		if o.Default == "1" {
+			s += ". 0: disabled, 1: enabled (default)"
+		} else {
+			s += ". 0: disabled (default), 1: enabled"
+		}
+		if o.Transactions != 0 {
+			s += ". Requires " + transactions(o.Transactions, "additional API transaction") + " if enabled"
+		}
+	case "int":
+		var limits []string
+		if o.Default != "" {
+			limits = append(limits, "default: "+o.Default)
+		}
+		if o.Max != 0 {
+			limits = append(limits, fmt.Sprintf("max: %d", o.Max))
+		}
+		if len(limits) != 0 {
+			s += " (" + strings.Join(limits, ", ") + ")"
+		}
+	case "enum":
+		s += ": " + strings.Join(o.Values, ", ")
+		if o.Default != "" {
+			s += " (default: " + o.Default + ")"
+		}
+	case "list":
+		s += ": VALUE,VALUE,... (possible VALUEs: " + strings.Join(o.Values, ",") + ")"
+		if o.Default != "" {
+			s += " (default: " + o.Default + ")"
+		}
+		if o.Transactions != 0 {
+			s += ". Each VALUE requires " + transactions(o.Transactions, "API transaction")
+		}
+	}
+	return s
+}
+
+// field returns the name of the option struct field of the option.
+func (o option) field() string {
+	return strings.ToUpper(o.name[:1]) + o.name[1:]
+}
+
+func (o option) goType() string {
+	if o.GoType != "" && o.Type == "enum" {
+		return o.GoType
+	}
+	switch o.Type {
+	case "bool":
+		return "*bool"
+	case "int":
+		return "int"
+	case "list":
+		return "[]string"
+	}
+	return "string"
+}
+
+// value returns the expression of the field of the option passed to its setter.
+func (o option) value() string {
+	if o.goType() != "string" && o.Type == "enum" {
+		return "string(o." + o.field() + ")"
+	}
+	return "o." + o.field()
+}
+
+// setter returns the optionValues method setting the option.
+func (o option) setter() string {
+	switch o.Type {
+	case "bool":
+		return "flag"
+	case "int":
+		return "int"
+	case "list":
+		return "list"
+	}
+	return "string"
+}
+
+// transactions returns "n what", in plural if n is not 1.
+func transactions(n int, what string) string {
+	if n == 1 {
+		return "1 " + what
+	}
+	return fmt.Sprintf("%d %ss", n, what)
+}
+
+// orList joins the docs of items as "a, b or c".
+func orList(items []string, doc func(string) string) string {
+	docs := make([]string, len(items))
+	for i, item := range items {
+		docs[i] = doc(item)
+	}
+	if len(docs) < 2 {
+		return strings.Join(docs, "")
+	}
+	return strings.Join(docs[:len(docs)-1], ", ") + " or " + docs[len(docs)-1]
+}
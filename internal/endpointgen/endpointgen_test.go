@@ -0,0 +1,64 @@
+package endpointgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate([]byte(`{
+		"entities": {"text": "/text/TextGetRankedNamedEntities"},
+		"pdf": {
+			"paths": {"url": "/url/URLGetPDF", "html": "/html/HTMLGetPDF"},
+			"options": {
+				"query": {"type": "string", "required": true, "doc": "what to look for"},
+				"pages": {"type": "int", "min": 1, "max": 10, "default": "5", "doc": "pages to read"},
+				"ocr": {"type": "bool", "transactions": 2, "doc": "read scanned pages"},
+				"layout": {"type": "enum", "goType": "Layout", "values": ["flow", "columns"], "doc": "how to read the text"}
+			},
+			"go": {"method": "PDF", "response": "PDFResponse", "doc": ["Reads a PDF."]}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// Code generated by gen.go from endpoints.json; DO NOT EDIT.",
+		"type EndpointAnalyzer interface {\n\tPDF(flavor string, data string, query string, options ...url.Values) (Result, error)\n",
+		"\tGetPDFContext(ctx context.Context, flavor string, data string, query string, options ...url.Values) (*PDFResponse, error)\n}",
+		"// Reads a PDF.\n// INPUT:\n// flavor -> which version of the call, i.e. url or html.\n",
+		"// query -> what to look for.\n",
+		"// pages -> pages to read (default: 5, max: 10)\n",
+		"// ocr -> read scanned pages. 0: disabled (default), 1: enabled. Requires 2 additional API transactions if enabled\n",
+		"func (a *Client) PDF(flavor string, data string, query string, options ...url.Values) (Result, error) {",
+		`return a.analyze(ctx, "pdf", flavor, data, withRequired(options, "query", query))`,
+		"func (a *Client) GetPDFContext(ctx context.Context, flavor string, data string, query string, options ...url.Values) (*PDFResponse, error) {",
+		"type PDFOptions struct {",
+		"\tPages int\n",
+		"\tOcr *bool\n",
+		`v.flag("ocr", o.Ocr)`,
+		"\tLayout Layout\n",
+		`v.string("layout", string(o.Layout))`,
+		`return ValidateOptions("pdf", o.Values())`,
+		"func (o PDFOptions) ValidateFor(endpoints *AlchemyAPI) error {\n\treturn endpoints.ValidateOptions(\"pdf\", o.Values())",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, src)
+		}
+	}
+	if strings.Contains(string(src), "Entities") || strings.Contains(string(src), "Query string") {
+		t.Errorf("unexpected generated code:\n%s", src)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, endpoints := range []string{
+		`[]`,
+		`{"pdf": {"paths": {"url": "/url/URLGetPDF"}, "go": {"method": "PDF"}}}`,
+		`{"pdf": {"paths": {"url": "/url/URLGetPDF"}, "options": {"pages": {"type": 1}}, "go": {"method": "PDF", "response": "PDFResponse"}}}`,
+	} {
+		if _, err := Generate([]byte(endpoints)); err == nil {
+			t.Errorf("Generate(%s) succeeded", endpoints)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package alchemyapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// journal is the checkpoint journal of a batch job: a JSON line per item
+	// analyzed, the last line of an item replacing the previous ones.
+	journal struct {
+		file    *os.File
+		entries map[string]journalEntry
+	}
+
+	// journalEntry is the outcome of an item in the runs of a job so far.
+	journalEntry struct {
+		ID string `json:"id"`
+		// Results are the responses of the actions that succeeded, in any run.
+		Results map[string]Result `json:"results,omitempty"`
+		// Errors are the messages of the actions that failed in the last run.
+		Errors map[string]string `json:"errors,omitempty"`
+		// Attempts is the number of runs that failed, not counting quota errors.
+		Attempts int `json:"attempts,omitempty"`
+		// Retry reports whether the item should be attempted again.
+		Retry bool `json:"retry,omitempty"`
+	}
+)
+
+// journalPath returns the path of the journal of the job.
+func journalPath(dir string, jobID string) (string, error) {
+	if jobID == "." || jobID == ".." || strings.ContainsAny(jobID, `/\`) {
+		return "", fmt.Errorf("batch: invalid job ID %q", jobID)
+	}
+	return filepath.Join(dir, jobID+".jsonl"), nil
+}
+
+// openJournal reads the journal at path, creating it if needed, and opens it for appending.
+func openJournal(path string) (*journal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	j := &journal{file: file, entries: map[string]journalEntry{}}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, DefaultMaxResponseSize*4)
+	for scanner.Scan() {
+		var entry journalEntry
+		// A line cut short by a crash is skipped, its item is analyzed again.
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && entry.ID != "" {
+			j.entries[entry.ID] = entry
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("reading %s: %v", path, err)
+	}
+	return j, nil
+}
+
+// append writes the entry to the journal and syncs it to disk.
+func (j *journal) append(entry journalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return j.file.Sync()
+}
+
+func (j *journal) Close() error {
+	return j.file.Close()
+}
+
+// done reports whether the item needs no further run for the actions.
+func (e journalEntry) done(actions []string, maxAttempts int) bool {
+	if len(e.Errors) != 0 {
+		return !e.Retry || (maxAttempts > 0 && e.Attempts >= maxAttempts)
+	}
+	for _, action := range actions {
+		if _, ok := e.Results[action]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// result returns the result of the item stored in the entry.
+func (e journalEntry) result(index int, item BatchItem, actions []string) BatchResult {
+	r := BatchResult{Item: item, Index: index, Results: map[string]Result{}, Errors: map[string]error{}, Resumed: true}
+	for _, action := range actions {
+		if result, ok := e.Results[action]; ok {
+			r.Results[action] = result
+		} else if message, ok := e.Errors[action]; ok {
+			r.Errors[action] = errors.New(message)
+			if r.Err == nil {
+				r.Err = fmt.Errorf("%s: %s", action, message)
+			}
+		}
+	}
+	return r
+}
+
+// RetryFailedItem is the default BatchConfig.RetryOn. It reports whether an item
+// that failed with err can succeed on another run, i.e. whether err is not an
+// invalid option or content over the size limit.
+func RetryFailedItem(err error) bool {
+	var optionErr *OptionError
+	return !errors.As(err, &optionErr) && !errors.Is(err, ErrContentExceedsSizeLimit)
+}
+
+// quotaError reports whether err means that no more calls can be made today.
+func quotaError(err error) bool {
+	return errors.Is(err, ErrDailyTransactionLimitExceeded) || errors.Is(err, ErrBudgetExceeded)
+}
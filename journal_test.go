@@ -0,0 +1,143 @@
+package alchemyapi_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func TestBatchJob(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	server.SetDailyLimit(6)
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	items := batchItems(10)
+	for i := range items {
+		items[i].ID = fmt.Sprintf("doc-%d", i)
+	}
+	items[1].Options = map[string]url.Values{"keywords": {"maxRetrieve": {"many"}}}
+	var progress alchemyapi.BatchProgress
+	config := alchemyapi.BatchConfig{
+		Actions:     []string{"entities", "keywords"},
+		Concurrency: 1,
+		Ordered:     true,
+		Progress:    func(p alchemyapi.BatchProgress) { progress = p },
+		JobID:       "nightly",
+		JournalDir:  t.TempDir(),
+		RetryOn:     func(err error) bool { return true },
+		MaxAttempts: 2,
+	}
+	run := func() []alchemyapi.BatchResult {
+		results, err := client.BatchItems(context.Background(), items, config)
+		assert.Equal(nil, err)
+		var all []alchemyapi.BatchResult
+		for r := range results {
+			all = append(all, r)
+		}
+		assert.Equal(10, len(all))
+		return all
+	}
+
+	// The quota runs out in the keywords call of doc-3, the other items are not sent.
+	results := run()
+	assert.Equal(6, server.Transactions())
+	assert.Equal(nil, results[0].Err)
+	var optionErr *alchemyapi.OptionError
+	assert.Equal(true, errors.As(results[1].Err, &optionErr))
+	assert.NotNil(results[3].Results["entities"])
+	for _, r := range results[3:] {
+		assert.Equal(true, errors.Is(r.Errors["keywords"], alchemyapi.ErrDailyTransactionLimitExceeded))
+	}
+	assert.Equal(alchemyapi.BatchProgress{Done: 10, Failed: 8, Total: 10}, progress)
+
+	// The next day only the calls that did not succeed are made.
+	server.SetDailyLimit(0)
+	results = run()
+	assert.Equal(6+1+12, server.Transactions())
+	assert.Equal(true, results[0].Resumed)
+	assert.Equal(false, results[1].Resumed)
+	assert.Equal(true, errors.As(results[1].Err, &optionErr))
+	for _, r := range results[2:] {
+		assert.Equal(nil, r.Err)
+	}
+	assert.Equal(alchemyapi.BatchProgress{Done: 10, Failed: 1, Resumed: 2, Total: 10}, progress)
+
+	// doc-1 failed twice, its error is final.
+	results = run()
+	assert.Equal(19, server.Transactions())
+	assert.Equal(true, results[1].Resumed)
+	assert.Equal("keywords: "+results[1].Errors["keywords"].Error(), results[1].Err.Error())
+	assert.NotNil(results[1].Results["entities"])
+	assert.Equal(alchemyapi.BatchProgress{Done: 10, Failed: 1, Resumed: 10, Total: 10}, progress)
+
+	config.JobID = "../nightly"
+	_, err := client.BatchItems(context.Background(), items, config)
+	assert.Equal(`batch: invalid job ID "../nightly"`, err.Error())
+}
+
+// cancelAfter is a RoundTripper that cancels the context of a batch once the response
+// to path is read, i.e. between two actions of an item.
+type cancelAfter struct {
+	path      string
+	cancel    context.CancelFunc
+	transport http.RoundTripper
+}
+
+func (c cancelAfter) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, err := c.transport.RoundTrip(req)
+	if err != nil || req.URL.Path != c.path {
+		return response, err
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	c.cancel()
+	return response, err
+}
+
+func TestBatchJobInterrupted(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	config := alchemyapi.BatchConfig{Actions: []string{"entities", "keywords"}, JobID: "nightly", JournalDir: t.TempDir()}
+	items := []alchemyapi.BatchItem{{ID: "doc", Flavor: "text", Data: "Bob broke my heart"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := alchemyapi.New(alchemytest.APIKey, server.URL, &http.Client{
+		Transport: cancelAfter{path: "/text/TextGetRankedNamedEntities", cancel: cancel, transport: server.Client().Transport},
+	})
+	results, err := interrupted.BatchItems(ctx, items, config)
+	assert.Equal(nil, err)
+	for range results {
+	}
+	assert.Equal(1, server.Requests())
+
+	// The entities call was paid for, only the keywords call is made.
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+	results, err = client.BatchItems(context.Background(), items, config)
+	assert.Equal(nil, err)
+	r := <-results
+	assert.Equal(nil, r.Err)
+	assert.Equal(2, len(r.Results))
+	assert.Equal(2, server.Requests())
+	for range results {
+	}
+}
+
+func TestRetryFailedItem(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	assert.Equal(false, alchemyapi.RetryFailedItem(&alchemyapi.OptionError{Endpoint: "entities", Option: "maxRetrieve"}))
+	assert.Equal(false, alchemyapi.RetryFailedItem(fmt.Errorf("entities: %w", alchemyapi.ErrContentExceedsSizeLimit)))
+	assert.Equal(true, alchemyapi.RetryFailedItem(alchemyapi.ErrCannotRetrieve))
+}
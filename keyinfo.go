@@ -0,0 +1,39 @@
+package alchemyapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// KeyInfo returns the transactions consumed today with the client's API key and its daily limit.
+// It costs no transactions.
+func (a *Client) KeyInfo() (*KeyInfoResponse, error) {
+	return a.KeyInfoContext(context.Background())
+}
+
+// KeyInfoContext is like KeyInfo but uses ctx for the request.
+func (a *Client) KeyInfoContext(ctx context.Context) (*KeyInfoResponse, error) {
+	v := &KeyInfoResponse{}
+	path, ok := a.api.Path("key_info", "info")
+	if !ok {
+		if apiErr != nil {
+			return v, apiErr
+		}
+		return v, errors.New("key info not available")
+	}
+	return v, a.post(ctx, &call{action: "key_info", flavor: "info", path: path, options: url.Values{}}, v)
+}
+
+// Ping checks that the service is reachable and accepts the client's API key,
+// e.g. at startup or in readiness probes. An invalid key fails with an
+// *APIError matching ErrInvalidAPIKey.
+func (a *Client) Ping() error {
+	return a.PingContext(context.Background())
+}
+
+// PingContext is like Ping but uses ctx for the request.
+func (a *Client) PingContext(ctx context.Context) error {
+	_, err := a.KeyInfoContext(ctx)
+	return err
+}
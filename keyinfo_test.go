@@ -0,0 +1,44 @@
+package alchemyapi_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	alchemyapi "github.com/ronna-s/alchemyapi_go"
+	"github.com/ronna-s/alchemyapi_go/alchemytest"
+)
+
+func TestKeyInfo(t *testing.T) {
+	assert := alchemyapi.NewAssert(t)
+	server := alchemytest.NewServer()
+	defer server.Close()
+	server.SetDailyLimit(1000)
+	client := alchemyapi.New(alchemytest.APIKey, server.URL, server.Client())
+
+	_, err := client.Entities("text", "Bob broke my heart", url.Values{"sentiment": {"1"}})
+	assert.Equal(nil, err)
+	info, err := client.KeyInfo()
+	assert.Equal(nil, err)
+	assert.Equal(alchemyapi.Int(2), info.ConsumedDailyTransactions)
+	assert.Equal(alchemyapi.Int(1000), info.DailyTransactionLimit)
+	assert.Equal(nil, client.PingContext(context.Background()))
+	assert.Equal(2, server.Transactions())
+
+	invalid := alchemyapi.New("invalid", server.URL, server.Client())
+	err = invalid.Ping()
+	assert.Equal(true, errors.Is(err, alchemyapi.ErrInvalidAPIKey))
+	var apiErr *alchemyapi.APIError
+	assert.Equal(true, errors.As(err, &apiErr))
+	assert.Equal("key_info", apiErr.Endpoint)
+
+	// Key info is free, so it is made with a spent budget.
+	budget := &alchemyapi.Budget{Path: filepath.Join(t.TempDir(), "budget.json"), Limit: 1, Reserve: 1}
+	client, err = alchemyapi.NewClient(alchemytest.APIKey, alchemyapi.WithBaseURL(server.URL), alchemyapi.WithBudget(budget))
+	assert.Equal(nil, err)
+	assert.Equal(nil, client.Ping())
+	_, err = client.Entities("text", "Bob")
+	assert.Equal(true, errors.Is(err, alchemyapi.ErrBudgetExceeded))
+}
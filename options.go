@@ -0,0 +1,261 @@
+package alchemyapi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ExtractMode is how the image extraction and combined calls find the page image.
+type ExtractMode string
+
+// Values of the extractMode option.
+const (
+	ExtractModeTrustMetadata       ExtractMode = "trust-metadata"
+	ExtractModeAlwaysInfer         ExtractMode = "always-infer"
+	ExtractModeAlwaysInferFallback ExtractMode = "always-infer-fallback"
+)
+
+// Values of the keywordExtractMode option of the keywords call.
+const (
+	KeywordExtractModeNormal = "normal"
+	KeywordExtractModeStrict = "strict"
+)
+
+// Types of the options in an EndpointSpec.
+const (
+	OptionString OptionType = "string"
+	// OptionBool is a flag, 0 or 1.
+	OptionBool OptionType = "bool"
+	// OptionInt is an integer between Min and Max; Max 0 means no upper bound.
+	OptionInt OptionType = "int"
+	// OptionEnum is one of Values.
+	OptionEnum OptionType = "enum"
+	// OptionList is a comma separated list of Values.
+	OptionList OptionType = "list"
+)
+
+type (
+	// OptionError is returned when an option is not accepted by an endpoint
+	// or has an invalid value. It is returned before anything is sent.
+	OptionError struct {
+		Endpoint string
+		Option   string
+		Value    string
+		Reason   string
+	}
+
+	// OptionType is the type of the values of an option.
+	OptionType string
+
+	// OptionSpec describes an option accepted by an endpoint.
+	OptionSpec struct {
+		Type OptionType `json:"type"`
+		// Values are the values of enum options and the items of list options.
+		Values []string `json:"values,omitempty"`
+		Min    int      `json:"min,omitempty"`
+		Max    int      `json:"max,omitempty"`
+		// Required options must be passed; the endpoint methods take them as arguments.
+		Required bool `json:"required,omitempty"`
+		// Default is the value the service uses when the option is not passed.
+		Default string `json:"default,omitempty"`
+		// Transactions is the extra cost of a call when a bool option is 1,
+		// or per item of a list option.
+		Transactions int    `json:"transactions,omitempty"`
+		Doc          string `json:"doc,omitempty"`
+	}
+)
+
+// commonOptions are accepted by every endpoint: the flavors, whose values the
+// endpoint methods set, url as the source URL of html calls, and the parameters the client sets.
+var commonOptions = map[string]OptionSpec{
+	"text":       {Type: OptionString},
+	"html":       {Type: OptionString},
+	"url":        {Type: OptionString},
+	"apikey":     {Type: OptionString},
+	"outputMode": {Type: OptionString},
+}
+
+// Bool returns a pointer to b, for the flags of the option structs.
+func Bool(b bool) *bool {
+	return &b
+}
+
+func (e *OptionError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s: option %s: %s", e.Endpoint, e.Option, e.Reason)
+	}
+	return fmt.Sprintf("%s: option %s=%s: %s", e.Endpoint, e.Option, e.Value, e.Reason)
+}
+
+// ValidateOptions checks that every option is accepted by the action, e.g. "entities",
+// and that its value is valid, according to the default endpoint table.
+// It returns an *OptionError otherwise.
+// Actions without known options, such as those registered at runtime, accept any option.
+func ValidateOptions(action string, options url.Values) error {
+	return api.validateOptions(action, options)
+}
+
+// ValidateOptions is like the package function ValidateOptions but checks the
+// options against the table, like a client using it does before sending them.
+func (api *AlchemyAPI) ValidateOptions(action string, options url.Values) error {
+	return api.validateOptions(action, options)
+}
+
+// validate checks the options of a call and the size of its content against the spec of the action.
+// A negative size is not checked.
+func (api *AlchemyAPI) validate(action string, flavor string, size int, options url.Values) error {
+	if err := api.validateOptions(action, options); err != nil {
+		return err
+	}
+	api.mu.RLock()
+	spec := api.specs[action]
+	api.mu.RUnlock()
+	if spec == nil {
+		return nil
+	}
+	for name, option := range spec.Options {
+		if option.Required && options.Get(name) == "" {
+			return &OptionError{Endpoint: action, Option: name, Reason: "required"}
+		}
+	}
+	if size < 0 {
+		return nil
+	}
+	if max, ok := spec.MaxInputSize[flavor]; ok && size > max {
+		return fmt.Errorf("%s: %s of %d bytes exceeds the limit of %d bytes: %w", action, flavor, size, max, ErrContentExceedsSizeLimit)
+	}
+	return nil
+}
+
+// maxInputSize returns the largest content of the action accepted for flavor, if limited.
+func (api *AlchemyAPI) maxInputSize(action string, flavor string) (int, bool) {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+	if spec := api.specs[action]; spec != nil {
+		max, ok := spec.MaxInputSize[flavor]
+		return max, ok
+	}
+	return 0, false
+}
+
+func (api *AlchemyAPI) validateOptions(action string, options url.Values) error {
+	api.mu.RLock()
+	spec := api.specs[action]
+	api.mu.RUnlock()
+	if spec == nil || spec.Options == nil {
+		return nil
+	}
+	for name, values := range options {
+		option, ok := spec.Options[name]
+		if !ok {
+			if option, ok = commonOptions[name]; !ok {
+				return &OptionError{Endpoint: action, Option: name, Reason: "not accepted by the endpoint"}
+			}
+		}
+		for _, value := range values {
+			if reason := option.check(value); reason != "" {
+				return &OptionError{Endpoint: action, Option: name, Value: value, Reason: reason}
+			}
+		}
+	}
+	return nil
+}
+
+// valid checks that the type of the option is known and that enums and lists have values.
+func (o OptionSpec) valid() error {
+	switch o.Type {
+	case OptionString, OptionBool, OptionInt:
+	case OptionEnum, OptionList:
+		if len(o.Values) == 0 {
+			return fmt.Errorf("%s without values", o.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %q", o.Type)
+	}
+	return nil
+}
+
+// check returns why value is invalid, or "" if it is valid.
+func (o OptionSpec) check(value string) string {
+	switch o.Type {
+	case OptionBool:
+		if value != "0" && value != "1" {
+			return "must be 0 or 1"
+		}
+	case OptionInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "must be an integer"
+		}
+		if n < o.Min {
+			return fmt.Sprintf("must be at least %d", o.Min)
+		}
+		if o.Max > 0 && n > o.Max {
+			return fmt.Sprintf("must be at most %d", o.Max)
+		}
+	case OptionEnum:
+		if !contains(o.Values, value) {
+			return "must be one of " + strings.Join(o.Values, ", ")
+		}
+	case OptionList:
+		for _, item := range strings.Split(value, ",") {
+			if !contains(o.Values, strings.TrimSpace(item)) {
+				return fmt.Sprintf("%q is not one of %s", item, strings.Join(o.Values, ", "))
+			}
+		}
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// optionValues builds the url.Values of the option structs.
+type optionValues url.Values
+
+func (v optionValues) flag(name string, b *bool) {
+	if b == nil {
+		return
+	}
+	if *b {
+		v[name] = []string{"1"}
+	} else {
+		v[name] = []string{"0"}
+	}
+}
+
+func (v optionValues) int(name string, n int) {
+	if n != 0 {
+		v[name] = []string{strconv.Itoa(n)}
+	}
+}
+
+func (v optionValues) string(name string, s string) {
+	if s != "" {
+		v[name] = []string{s}
+	}
+}
+
+func (v optionValues) list(name string, items []string) {
+	v.string(name, strings.Join(items, ","))
+}
+
+// withRequired returns a copy of the options with the required options of an
+// endpoint method, given as name and value pairs, set if they are not empty.
+func withRequired(options []url.Values, nameValues ...string) url.Values {
+	opts := optionsOf(options...)
+	for i := 0; i+1 < len(nameValues); i += 2 {
+		if nameValues[i+1] != "" {
+			opts[nameValues[i]] = []string{nameValues[i+1]}
+		}
+	}
+	return opts
+}
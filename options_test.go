@@ -0,0 +1,85 @@
+package alchemyapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOptionValues(t *testing.T) {
+	assert := NewAssert(t)
+	assert.Equal(url.Values{"disambiguate": {"1"}, "sentiment": {"0"}, "maxRetrieve": {"20"}},
+		EntitiesOptions{Disambiguate: Bool(true), Sentiment: Bool(false), MaxRetrieve: 20}.Values())
+	assert.Equal(url.Values{}, EntitiesOptions{}.Values())
+	assert.Equal(url.Values{"extract": {"entity,title"}, "extractMode": {"always-infer"}},
+		CombinedOptions{Extract: []string{"entity", "title"}, ExtractMode: ExtractModeAlwaysInfer}.Values())
+	assert.Equal(nil, RelationsOptions{MaxRetrieve: 100}.Validate())
+	assert.Equal(nil, KeywordsOptions{KeywordExtractMode: KeywordExtractModeStrict}.Validate())
+}
+
+func TestValidateOptions(t *testing.T) {
+	assert := NewAssert(t)
+	var optErr *OptionError
+
+	err := RelationsOptions{MaxRetrieve: 101}.Validate()
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal("relations", optErr.Endpoint)
+	assert.Equal("maxRetrieve", optErr.Option)
+	assert.Equal("101", optErr.Value)
+	assert.Equal("relations: option maxRetrieve=101: must be at most 100", err.Error())
+
+	err = KeywordsOptions{KeywordExtractMode: "loose"}.Validate()
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal("keywordExtractMode", optErr.Option)
+
+	err = CombinedOptions{Extract: []string{"entity", "entities"}}.Validate()
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal("extract", optErr.Option)
+
+	err = ValidateOptions("entities", url.Values{"disambiguate": {"yes"}})
+	assert.Equal("entities: option disambiguate=yes: must be 0 or 1", err.Error())
+	err = ValidateOptions("entities", url.Values{"maxRetreive": {"5"}})
+	assert.Equal("entities: option maxRetreive: not accepted by the endpoint", err.Error())
+	err = ValidateOptions("sentiment", url.Values{"maxRetrieve": {"5"}})
+	assert.Equal(true, errors.As(err, &optErr))
+
+	assert.Equal(nil, ValidateOptions("entities", url.Values{"url": {"http://example.com/"}, "sourceText": {"cquery"}, "cquery": {"title"}}))
+	assert.Equal(nil, ValidateOptions("custom", url.Values{"anything": {"goes"}}))
+
+	// A client with its own table validates against it.
+	a, err := NewClient("key", WithEndpoints(strings.NewReader(`{"relations": {"paths": {"text": "/text/TextGetRelations"}, "options": {"maxRetrieve": {"type": "int", "max": 200}}}}`)))
+	assert.Equal(nil, err)
+	assert.Equal(nil, RelationsOptions{MaxRetrieve: 150}.ValidateFor(a.Endpoints()))
+	assert.NotNil(RelationsOptions{MaxRetrieve: 150}.Validate())
+	err = RelationsOptions{Sentiment: Bool(true)}.ValidateFor(a.Endpoints())
+	assert.Equal("relations: option sentiment: not accepted by the endpoint", err.Error())
+	assert.Equal(nil, a.Endpoints().ValidateOptions("relations", url.Values{"maxRetrieve": {"200"}}))
+}
+
+func TestInvalidOptionsNotSent(t *testing.T) {
+	assert := NewAssert(t)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+	a := New("key", server.URL, server.Client())
+
+	var optErr *OptionError
+	_, err := a.Relations("text", "Bob", RelationsOptions{MaxRetrieve: 500}.Values())
+	assert.Equal(true, errors.As(err, &optErr))
+	_, err = a.GetTitle("html", "<title>Bob</title>", url.Values{"linkedData": {"1"}})
+	assert.Equal(true, errors.As(err, &optErr))
+	_, err = a.ImageTags("image", "\x89PNG", url.Values{"forceShowAll": {"maybe"}})
+	assert.Equal(true, errors.As(err, &optErr))
+	assert.Equal(int32(0), atomic.LoadInt32(&calls))
+
+	_, err = a.Entities("html", "<p>Bob</p>", url.Values{"linkedData": {"0"}, "url": {"http://example.com/"}})
+	assert.Equal(nil, err)
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+}
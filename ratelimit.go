@@ -0,0 +1,201 @@
+package alchemyapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimit limits the requests of a client, or of one endpoint of it.
+	// Requests over the limit wait for a slot, or fail with the error of their context.
+	// Every attempt of a retried call is a request.
+	RateLimit struct {
+		// Rate is the sustained number of requests per second, 0 means no rate limit.
+		Rate float64
+		// Burst is the number of requests that can be sent at once at the start
+		// or after a pause, at least 1.
+		Burst int
+		// MaxInFlight is the number of requests in progress at a time, 0 means no limit.
+		MaxInFlight int
+	}
+
+	// LimiterStats are the statistics of a rate limiter.
+	LimiterStats struct {
+		// Requests is the number of requests that went through the limiter.
+		Requests int64
+		// Waited is the number of requests that had to wait for a slot.
+		Waited int64
+		// WaitTime is the total and MaxWait the longest time requests waited.
+		WaitTime time.Duration
+		MaxWait  time.Duration
+		// InFlight is the number of requests in progress.
+		InFlight int
+	}
+
+	// limiter is a token bucket and a semaphore.
+	limiter struct {
+		limit RateLimit
+		sem   chan struct{}
+
+		mu     sync.Mutex
+		tokens float64
+		last   time.Time
+		stats  LimiterStats
+	}
+)
+
+func newLimiter(limit RateLimit) *limiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	l := &limiter{limit: limit, tokens: float64(limit.Burst)}
+	if limit.MaxInFlight > 0 {
+		l.sem = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// wait blocks until the request can be sent and returns the function to call when it is done.
+func (l *limiter) wait(ctx context.Context) (release func(), err error) {
+	start := time.Now()
+	if err := l.take(ctx); err != nil {
+		return nil, err
+	}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			l.giveBack()
+			return nil, ctx.Err()
+		}
+	}
+	waited := time.Since(start)
+
+	l.mu.Lock()
+	l.stats.Requests++
+	l.stats.InFlight++
+	// Waits shorter than a millisecond are scheduling noise, not throttling.
+	if waited >= time.Millisecond {
+		l.stats.Waited++
+		l.stats.WaitTime += waited
+		if waited > l.stats.MaxWait {
+			l.stats.MaxWait = waited
+		}
+	}
+	l.mu.Unlock()
+	return l.release, nil
+}
+
+// take takes a token from the bucket, waiting for it if there is none.
+// Tokens are reserved in order, so waiting requests are served first come, first served.
+func (l *limiter) take(ctx context.Context) error {
+	if l.limit.Rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.limit.Rate
+		if l.tokens > float64(l.limit.Burst) {
+			l.tokens = float64(l.limit.Burst)
+		}
+	}
+	l.last = now
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.limit.Rate * float64(time.Second))
+	l.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.giveBack()
+		return ctx.Err()
+	}
+}
+
+// giveBack returns the token taken by a request that was canceled before being sent.
+func (l *limiter) giveBack() {
+	if l.limit.Rate <= 0 {
+		return
+	}
+	l.mu.Lock()
+	l.tokens++
+	l.mu.Unlock()
+}
+
+func (l *limiter) release() {
+	if l.sem != nil {
+		<-l.sem
+	}
+	l.mu.Lock()
+	l.stats.InFlight--
+	l.mu.Unlock()
+}
+
+func (l *limiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// WithRateLimit limits the requests of the client, see RateLimit.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *clientConfig) error {
+		c.client.limiter = newLimiter(limit)
+		return nil
+	}
+}
+
+// WithEndpointRateLimit limits the requests of the client to the action, e.g. "combined",
+// in addition to the limit of WithRateLimit.
+func WithEndpointRateLimit(action string, limit RateLimit) Option {
+	return func(c *clientConfig) error {
+		if c.client.endpointLimiters == nil {
+			c.client.endpointLimiters = map[string]*limiter{}
+		}
+		c.client.endpointLimiters[action] = newLimiter(limit)
+		return nil
+	}
+}
+
+// LimiterStats returns the statistics of the rate limit of the action set with
+// WithEndpointRateLimit, or of the client set with WithRateLimit if action is "".
+// It reports false if there is no such limit.
+func (a *Client) LimiterStats(action string) (LimiterStats, bool) {
+	l := a.limiter
+	if action != "" {
+		l = a.endpointLimiters[action]
+	}
+	if l == nil {
+		return LimiterStats{}, false
+	}
+	return l.Stats(), true
+}
+
+// waitLimits waits for the rate limits of the action and of the client.
+func (a *Client) waitLimits(ctx context.Context, action string) (release func(), err error) {
+	var releases []func()
+	release = func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+	for _, l := range []*limiter{a.endpointLimiters[action], a.limiter} {
+		if l == nil {
+			continue
+		}
+		r, err := l.wait(ctx)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
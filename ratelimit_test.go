@@ -0,0 +1,151 @@
+package alchemyapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	assert := NewAssert(t)
+	l := newLimiter(RateLimit{Rate: 50, Burst: 2})
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		release, err := l.wait(context.Background())
+		assert.Equal(nil, err)
+		release()
+	}
+	// The burst goes through at once, the 2 other requests wait 20ms each.
+	elapsed := time.Since(start)
+	assert.Equal(true, elapsed >= 35*time.Millisecond)
+	stats := l.Stats()
+	assert.Equal(int64(4), stats.Requests)
+	assert.Equal(int64(2), stats.Waited)
+	assert.Equal(true, stats.MaxWait > 0 && stats.WaitTime >= stats.MaxWait)
+	assert.Equal(0, stats.InFlight)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	l = newLimiter(RateLimit{Rate: 1})
+	release, err := l.wait(ctx)
+	assert.Equal(nil, err)
+	release()
+	_, err = l.wait(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+	// The token reserved by the canceled request was given back.
+	assert.Equal(true, l.tokens > -0.5)
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	assert := NewAssert(t)
+	l := newLimiter(RateLimit{MaxInFlight: 1})
+	release, err := l.wait(context.Background())
+	assert.Equal(nil, err)
+	assert.Equal(1, l.Stats().InFlight)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.wait(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	done := make(chan error)
+	go func() {
+		r, err := l.wait(context.Background())
+		if err == nil {
+			r()
+		}
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	release()
+	assert.Equal(nil, <-done)
+	stats := l.Stats()
+	assert.Equal(int64(2), stats.Requests)
+	assert.Equal(int64(1), stats.Waited)
+	assert.Equal(0, stats.InFlight)
+
+	// A request canceled while waiting for a slot gives its token back.
+	l = newLimiter(RateLimit{Rate: 1, Burst: 2, MaxInFlight: 1})
+	release, err = l.wait(context.Background())
+	assert.Equal(nil, err)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.wait(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+	release()
+	l.mu.Lock()
+	assert.Equal(true, l.tokens > 0.5)
+	l.mu.Unlock()
+}
+
+// inFlight tracks the number of requests in progress and its maximum.
+type inFlight struct {
+	n, max int32
+}
+
+func (f *inFlight) start() {
+	n := atomic.AddInt32(&f.n, 1)
+	for {
+		m := atomic.LoadInt32(&f.max)
+		if n <= m || atomic.CompareAndSwapInt32(&f.max, m, n) {
+			return
+		}
+	}
+}
+
+func (f *inFlight) done() {
+	atomic.AddInt32(&f.n, -1)
+}
+
+func TestClientRateLimit(t *testing.T) {
+	assert := NewAssert(t)
+	var all, entities inFlight
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		all.start()
+		defer all.done()
+		if r.URL.Path == "/text/TextGetRankedNamedEntities" {
+			entities.start()
+			defer entities.done()
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+	a, err := NewClient("key", WithBaseURL(server.URL), WithHTTPClient(server.Client()),
+		WithRateLimit(RateLimit{MaxInFlight: 4}),
+		WithEndpointRateLimit("entities", RateLimit{MaxInFlight: 2}))
+	assert.Equal(nil, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := a.Entities("text", "Bob")
+			assert.Equal(nil, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := a.Keywords("text", "Bob")
+			assert.Equal(nil, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(true, atomic.LoadInt32(&all.max) <= 4)
+	assert.Equal(true, atomic.LoadInt32(&entities.max) <= 2)
+	assert.Equal(true, atomic.LoadInt32(&entities.max) >= 1)
+
+	stats, ok := a.LimiterStats("")
+	assert.Equal(true, ok)
+	assert.Equal(int64(20), stats.Requests)
+	assert.Equal(0, stats.InFlight)
+	stats, ok = a.LimiterStats("entities")
+	assert.Equal(true, ok)
+	assert.Equal(int64(10), stats.Requests)
+	_, ok = a.LimiterStats("keywords")
+	assert.Equal(false, ok)
+}
@@ -0,0 +1,324 @@
+package alchemyapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+type (
+	// Float is a float64 that also decodes from the quoted numbers AlchemyAPI sends ("0.93").
+	// Empty strings and null decode to 0.
+	Float float64
+
+	// Int is an int that also decodes from the quoted numbers AlchemyAPI sends ("12").
+	// Empty strings and null decode to 0.
+	Int int
+
+	// Response holds the fields common to every AlchemyAPI response.
+	Response struct {
+		Status            string `json:"status"`
+		StatusInfo        string `json:"statusInfo,omitempty"`
+		Usage             string `json:"usage,omitempty"`
+		URL               string `json:"url,omitempty"`
+		Language          string `json:"language,omitempty"`
+		TotalTransactions Int    `json:"totalTransactions,omitempty"`
+	}
+
+	// Sentiment is a sentiment score as returned on documents, entities, keywords and relations.
+	Sentiment struct {
+		Type  string `json:"type"`
+		Score Float  `json:"score"`
+		Mixed Int    `json:"mixed,omitempty"`
+	}
+
+	// LinkedData holds the linked data links returned with disambiguated entities and concepts.
+	LinkedData struct {
+		Website            string `json:"website,omitempty"`
+		Geo                string `json:"geo,omitempty"`
+		Dbpedia            string `json:"dbpedia,omitempty"`
+		Freebase           string `json:"freebase,omitempty"`
+		Yago               string `json:"yago,omitempty"`
+		Opencyc            string `json:"opencyc,omitempty"`
+		CiaFactbook        string `json:"ciaFactbook,omitempty"`
+		Census             string `json:"census,omitempty"`
+		Geonames           string `json:"geonames,omitempty"`
+		MusicBrainz        string `json:"musicBrainz,omitempty"`
+		Crunchbase         string `json:"crunchbase,omitempty"`
+		SemanticCrunchbase string `json:"semanticCrunchbase,omitempty"`
+	}
+
+	// Disambiguated describes the entity an extracted entity was resolved to.
+	Disambiguated struct {
+		Name    string   `json:"name"`
+		SubType []string `json:"subType,omitempty"`
+		LinkedData
+	}
+
+	// Quotation is a quotation attributed to an entity.
+	Quotation struct {
+		Quotation string `json:"quotation"`
+	}
+
+	// Entity is a named entity extracted by the entities call.
+	Entity struct {
+		Type          string         `json:"type"`
+		Relevance     Float          `json:"relevance"`
+		Count         Int            `json:"count"`
+		Text          string         `json:"text"`
+		Sentiment     *Sentiment     `json:"sentiment,omitempty"`
+		Disambiguated *Disambiguated `json:"disambiguated,omitempty"`
+		Quotations    []Quotation    `json:"quotations,omitempty"`
+	}
+
+	// Keyword is a keyword extracted by the keywords call.
+	Keyword struct {
+		Text      string     `json:"text"`
+		Relevance Float      `json:"relevance"`
+		Sentiment *Sentiment `json:"sentiment,omitempty"`
+	}
+
+	// Concept is a concept tagged by the concepts call.
+	Concept struct {
+		Text      string `json:"text"`
+		Relevance Float  `json:"relevance"`
+		LinkedData
+	}
+
+	// Verb is the verb of a relation action.
+	Verb struct {
+		Text    string `json:"text"`
+		Tense   string `json:"tense,omitempty"`
+		Negated Int    `json:"negated,omitempty"`
+	}
+
+	// RelationAction is the action part of a relation.
+	RelationAction struct {
+		Text       string `json:"text"`
+		Lemmatized string `json:"lemmatized,omitempty"`
+		Verb       Verb   `json:"verb"`
+	}
+
+	// RelationPart is the subject, object or location of a relation.
+	RelationPart struct {
+		Text                 string     `json:"text"`
+		Sentiment            *Sentiment `json:"sentiment,omitempty"`
+		SentimentFromSubject *Sentiment `json:"sentimentFromSubject,omitempty"`
+		Entities             []Entity   `json:"entities,omitempty"`
+		Keywords             []Keyword  `json:"keywords,omitempty"`
+	}
+
+	// Relation is a subject-action-object relation extracted by the relations call.
+	Relation struct {
+		Subject  *RelationPart  `json:"subject,omitempty"`
+		Action   RelationAction `json:"action"`
+		Object   *RelationPart  `json:"object,omitempty"`
+		Location *RelationPart  `json:"location,omitempty"`
+	}
+
+	// TaxonomyCategory is a category returned by the taxonomy call.
+	TaxonomyCategory struct {
+		Label     string `json:"label"`
+		Score     Float  `json:"score"`
+		Confident string `json:"confident,omitempty"`
+	}
+
+	// Microformat is a single parsed microformat field.
+	Microformat struct {
+		Field string `json:"field"`
+		Data  string `json:"data"`
+	}
+
+	// Feed is a detected RSS/ATOM feed link.
+	Feed struct {
+		Feed string `json:"feed"`
+	}
+
+	// ImageKeyword is a tag assigned to an image by the image tagging call.
+	ImageKeyword struct {
+		Text  string `json:"text"`
+		Score Float  `json:"score"`
+	}
+
+	// SentimentResponse is the response of the sentiment and targeted sentiment calls.
+	SentimentResponse struct {
+		Response
+		Text         string    `json:"text,omitempty"`
+		DocSentiment Sentiment `json:"docSentiment"`
+	}
+
+	// EntitiesResponse is the response of the entities call.
+	EntitiesResponse struct {
+		Response
+		Text     string   `json:"text,omitempty"`
+		Entities []Entity `json:"entities"`
+	}
+
+	// AuthorResponse is the response of the author call.
+	AuthorResponse struct {
+		Response
+		Author string `json:"author"`
+	}
+
+	// KeywordsResponse is the response of the keywords call.
+	KeywordsResponse struct {
+		Response
+		Text     string    `json:"text,omitempty"`
+		Keywords []Keyword `json:"keywords"`
+	}
+
+	// ConceptsResponse is the response of the concepts call.
+	ConceptsResponse struct {
+		Response
+		Text     string    `json:"text,omitempty"`
+		Concepts []Concept `json:"concepts"`
+	}
+
+	// CategoryResponse is the response of the category call.
+	CategoryResponse struct {
+		Response
+		Text     string `json:"text,omitempty"`
+		Category string `json:"category"`
+		Score    Float  `json:"score"`
+	}
+
+	// RelationsResponse is the response of the relations call.
+	RelationsResponse struct {
+		Response
+		Text      string     `json:"text,omitempty"`
+		Relations []Relation `json:"relations"`
+	}
+
+	// LanguageResponse is the response of the language call. The detected
+	// language name is in the embedded Response.Language.
+	LanguageResponse struct {
+		Response
+		ISO6391        string `json:"iso-639-1"`
+		ISO6392        string `json:"iso-639-2"`
+		ISO6393        string `json:"iso-639-3"`
+		Ethnologue     string `json:"ethnologue,omitempty"`
+		NativeSpeakers string `json:"native-speakers,omitempty"`
+		Wikipedia      string `json:"wikipedia,omitempty"`
+	}
+
+	// TextResponse is the response of the text and raw text calls.
+	TextResponse struct {
+		Response
+		Text string `json:"text"`
+	}
+
+	// TitleResponse is the response of the title call.
+	TitleResponse struct {
+		Response
+		Title string `json:"title"`
+	}
+
+	// MicroformatsResponse is the response of the microformats call.
+	MicroformatsResponse struct {
+		Response
+		Microformats []Microformat `json:"microformats"`
+	}
+
+	// FeedsResponse is the response of the feeds call.
+	FeedsResponse struct {
+		Response
+		Feeds []Feed `json:"feeds"`
+	}
+
+	// TaxonomyResponse is the response of the taxonomy call.
+	TaxonomyResponse struct {
+		Response
+		Text     string             `json:"text,omitempty"`
+		Taxonomy []TaxonomyCategory `json:"taxonomy"`
+	}
+
+	// CombinedResponse is the response of the combined call. Only the sections
+	// requested through the extract option are populated.
+	CombinedResponse struct {
+		Response
+		Text         string             `json:"text,omitempty"`
+		Title        string             `json:"title,omitempty"`
+		Author       string             `json:"author,omitempty"`
+		Image        string             `json:"image,omitempty"`
+		DocSentiment *Sentiment         `json:"docSentiment,omitempty"`
+		Entities     []Entity           `json:"entities,omitempty"`
+		Keywords     []Keyword          `json:"keywords,omitempty"`
+		Concepts     []Concept          `json:"concepts,omitempty"`
+		Relations    []Relation         `json:"relations,omitempty"`
+		Taxonomy     []TaxonomyCategory `json:"taxonomy,omitempty"`
+	}
+
+	// ImageTagsResponse is the response of the image tagging call.
+	ImageTagsResponse struct {
+		Response
+		ImageKeywords []ImageKeyword `json:"imageKeywords"`
+	}
+
+	// ImageExtractResponse is the response of the image extraction call.
+	ImageExtractResponse struct {
+		Response
+		Image string `json:"image"`
+	}
+
+	// KeyInfoResponse is the response of the API key info call.
+	KeyInfoResponse struct {
+		Response
+		ConsumedDailyTransactions Int `json:"consumedDailyTransactions"`
+		DailyTransactionLimit     Int `json:"dailyTransactionLimit"`
+	}
+)
+
+// unquoteNumber strips the quotes AlchemyAPI puts around numbers and reports
+// whether anything is left to parse.
+func unquoteNumber(data []byte) ([]byte, bool, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, false, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, false, err
+		}
+		data = bytes.TrimSpace([]byte(s))
+	}
+	return data, len(data) > 0, nil
+}
+
+// Float64 returns f as a float64.
+func (f Float) Float64() float64 {
+	return float64(f)
+}
+
+func (f *Float) UnmarshalJSON(data []byte) error {
+	data, ok, err := unquoteNumber(data)
+	if err != nil || !ok {
+		*f = 0
+		return err
+	}
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*f = Float(v)
+	return nil
+}
+
+// Int returns i as an int.
+func (i Int) Int() int {
+	return int(i)
+}
+
+func (i *Int) UnmarshalJSON(data []byte) error {
+	data, ok, err := unquoteNumber(data)
+	if err != nil || !ok {
+		*i = 0
+		return err
+	}
+	v, err := strconv.ParseInt(string(data), 10, 0)
+	if err != nil {
+		return err
+	}
+	*i = Int(v)
+	return nil
+}
@@ -0,0 +1,141 @@
+package alchemyapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNumberDecoding(t *testing.T) {
+	assert := NewAssert(t)
+	var v struct {
+		A, B, C, D Float
+		E, F, G    Int
+	}
+	err := json.Unmarshal([]byte(`{"A":"0.93","B":0.5,"C":"","D":null,"E":"12","F":3,"G":""}`), &v)
+	assert.Equal(nil, err)
+	assert.Equal(0.93, v.A)
+	assert.Equal(0.5, v.B)
+	assert.Equal(0.0, v.C)
+	assert.Equal(0.0, v.D)
+	assert.Equal(12, v.E)
+	assert.Equal(3, v.F)
+	assert.Equal(0, v.G)
+	assert.Equal(float64(0.93), v.A.Float64())
+	assert.Equal(int(12), v.E.Int())
+	assert.NotNil(json.Unmarshal([]byte(`{"A":"high"}`), &v))
+}
+
+func TestTypedResponses(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/text/TextGetRankedNamedEntities":
+			w.Write([]byte(`{"status":"OK","usage":"","language":"english","totalTransactions":"2",
+				"entities":[{"type":"Person","relevance":"0.93","count":"3","text":"Bob",
+				"sentiment":{"type":"negative","score":"-0.52"},
+				"disambiguated":{"name":"Bob Dylan","subType":["Musician"],"dbpedia":"http://dbpedia.org/resource/Bob_Dylan"}}]}`))
+		default:
+			w.Write([]byte(`{"status":"ERROR","statusInfo":"invalid-api-key"}`))
+		}
+	}))
+	defer server.Close()
+	a := New("key", server.URL, server.Client())
+
+	entities, err := a.GetEntities("text", "Bob broke my heart")
+	assert.Equal(nil, err)
+	assert.Equal("OK", entities.Status)
+	assert.Equal(2, entities.TotalTransactions)
+	assert.Equal(1, len(entities.Entities))
+	e := entities.Entities[0]
+	assert.Equal("Bob", e.Text)
+	assert.Equal(0.93, e.Relevance)
+	assert.Equal(3, e.Count)
+	assert.Equal(-0.52, e.Sentiment.Score)
+	assert.Equal("Bob Dylan", e.Disambiguated.Name)
+	assert.Equal("http://dbpedia.org/resource/Bob_Dylan", e.Disambiguated.Dbpedia)
+
+	keywords, err := a.GetKeywords("text", "Bob broke my heart")
+	assert.NotNil(err)
+	assert.Equal("ERROR", keywords.Status)
+	assert.Equal("invalid-api-key", keywords.StatusInfo)
+
+	_, err = a.GetSentimentTargeted("text", "Bob broke my heart", "")
+	assert.NotNil(err)
+}
+
+func TestImageTags(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image/ImageGetRankedImageKeywords":
+			body, _ := ioutil.ReadAll(r.Body)
+			if r.URL.Query().Get("imagePostMode") != "raw" || r.URL.Query().Get("apikey") != "key" || string(body) != "\x89PNG" {
+				w.Write([]byte(`{"status":"ERROR","statusInfo":"invalid-image"}`))
+				return
+			}
+		case "/url/URLGetRankedImageKeywords":
+			if r.FormValue("url") != "http://example.com/cat.jpg" {
+				w.Write([]byte(`{"status":"ERROR","statusInfo":"invalid-url"}`))
+				return
+			}
+		}
+		w.Write([]byte(`{"status":"OK","totalTransactions":"4","imageKeywords":[{"text":"cat","score":"0.98"}]}`))
+	}))
+	defer server.Close()
+	a := New("key", server.URL, server.Client())
+
+	tags, err := a.GetImageTagsFromReader(strings.NewReader("\x89PNG"))
+	assert.Equal(nil, err)
+	assert.Equal("cat", tags.ImageKeywords[0].Text)
+	assert.Equal(0.98, tags.ImageKeywords[0].Score)
+	tags, err = a.GetImageTags("url", "http://example.com/cat.jpg")
+	assert.Equal(nil, err)
+	assert.Equal(4, tags.TotalTransactions)
+	response, err := a.ImageTags("image", "\x89PNG")
+	assert.Equal(nil, err)
+	assert.Equal("OK", response["status"])
+	_, err = a.ImageTags("text", "cat")
+	assert.NotNil(err)
+
+	// The API key sent in the query string of raw images is not part of transport errors.
+	server.Close()
+	a = New("SECRETKEY", server.URL, server.Client())
+	_, err = a.GetImageTagsFromReader(strings.NewReader("\x89PNG"))
+	assert.NotNil(err)
+	assert.Equal(false, strings.Contains(err.Error(), "SECRETKEY"))
+	assert.Equal(true, strings.Contains(err.Error(), "imagePostMode=raw"))
+}
+
+func TestImageExtract(t *testing.T) {
+	assert := NewAssert(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/url/URLGetImage":
+			if r.FormValue("url") == "http://example.com/empty" {
+				w.Write([]byte(`{"status":"OK","url":"http://example.com/empty","image":""}`))
+				return
+			}
+			w.Write([]byte(`{"status":"OK","url":"http://example.com/","image":"http://example.com/cat.jpg"}`))
+		case "/url/URLGetRankedImageKeywords":
+			w.Write([]byte(`{"status":"OK","url":"` + r.FormValue("url") + `","imageKeywords":[{"text":"cat","score":"0.98"}]}`))
+		}
+	}))
+	defer server.Close()
+	a := New("key", server.URL, server.Client())
+
+	image, err := a.GetImageExtract("url", "http://example.com/", ImageExtractOptions{ExtractMode: ExtractModeAlwaysInfer}.Values())
+	assert.Equal(nil, err)
+	assert.Equal("http://example.com/cat.jpg", image.Image)
+	_, err = a.ImageExtract("url", "http://example.com/", map[string][]string{"extractMode": {"guess"}})
+	assert.NotNil(err)
+	image, tags, err := a.GetExtractedImageTags("url", "http://example.com/")
+	assert.Equal(nil, err)
+	assert.Equal("http://example.com/cat.jpg", tags.URL)
+	assert.Equal("cat", tags.ImageKeywords[0].Text)
+	_, _, err = a.GetExtractedImageTags("url", "http://example.com/empty")
+	assert.Equal(ErrNoImage, err)
+}
@@ -0,0 +1,239 @@
+package alchemyapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/url"
+	"reflect"
+	"syscall"
+	"time"
+)
+
+type (
+	// RetryPolicy controls how a client retries failed requests.
+	// A nil policy, or one with MaxAttempts below 2, makes a single attempt.
+	RetryPolicy struct {
+		// MaxAttempts is the total number of attempts, including the first one.
+		MaxAttempts int
+		// InitialBackoff is the wait before the first retry (default 500ms).
+		InitialBackoff time.Duration
+		// MaxBackoff caps the wait between attempts (default 30s).
+		MaxBackoff time.Duration
+		// Multiplier grows the backoff after every retry (default 2).
+		Multiplier float64
+		// Jitter randomizes each wait by up to this fraction of it, between 0 and 1.
+		Jitter float64
+		// RetryOn reports whether err is worth another attempt (default Retryable).
+		RetryOn func(err error) bool
+		// OnRetry, if set, is called before waiting for the given retry attempt (2 for the first retry).
+		OnRetry func(attempt int, err error, wait time.Duration)
+	}
+)
+
+// DefaultRetryPolicy returns a policy making up to 3 attempts with
+// exponential backoff starting at 500ms and 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, InitialBackoff: 500 * time.Millisecond, MaxBackoff: 30 * time.Second, Multiplier: 2, Jitter: 0.2}
+}
+
+// permanentKinds are the API errors retrying cannot fix, whatever the HTTP status of their response.
+var permanentKinds = map[ErrorKind]bool{
+	ErrInvalidAPIKey:                 true,
+	ErrDailyTransactionLimitExceeded: true,
+	ErrContentExceedsSizeLimit:       true,
+	ErrUnsupportedTextLanguage:       true,
+	ErrInvalidURL:                    true,
+	ErrPageIsNotHTML:                 true,
+	ErrContentIsEmpty:                true,
+}
+
+// Retryable reports whether err is a transient failure: a network error such as a
+// timeout or a reset connection (but not an invalid URL or certificate), an
+// HTTP 429 or 5xx response, or a cannot-retrieve error caused by the target site
+// answering 429 or 5xx. ERROR responses sent with a 429 or 5xx status are
+// transient too, unless their statusInfo is permanent, like invalid-api-key or
+// unsupported-text-language. Context errors and other API errors are permanent.
+func Retryable(err error) bool {
+	var (
+		apiErr  *APIError
+		respErr *ResponseError
+		urlErr  *url.Error
+	)
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case errors.As(err, &apiErr):
+		if apiErr.Kind == ErrCannotRetrieve && apiErr.SubCode != 0 {
+			return apiErr.SubCode == 429 || apiErr.SubCode >= 500
+		}
+		return (apiErr.StatusCode == 429 || apiErr.StatusCode >= 500) && !permanentKinds[apiErr.Kind]
+	case errors.As(err, &respErr):
+		return respErr.StatusCode == 429 || respErr.StatusCode >= 500
+	case errors.As(err, &urlErr):
+		// Transport failures from the http.Client are reported as *url.Error.
+		return transient(urlErr.Err)
+	default:
+		return transient(err)
+	}
+}
+
+// transient reports whether the transport error err may not happen again: a
+// timeout, a refused or reset connection, a DNS failure that is not a missing
+// host, or a connection closed early. Certificate errors and invalid URLs or
+// schemes are permanent.
+func transient(err error) bool {
+	var (
+		dnsErr    *net.DNSError
+		opErr     *net.OpError
+		netErr    net.Error
+		certErr   *tls.CertificateVerificationError
+		recordErr tls.RecordHeaderError
+		unknownCA x509.UnknownAuthorityError
+		invalid   x509.CertificateInvalidError
+		hostErr   x509.HostnameError
+	)
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &unknownCA),
+		errors.As(err, &invalid), errors.As(err, &hostErr):
+		return false
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.EPIPE):
+		return true
+	case errors.As(err, &dnsErr):
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	case errors.As(err, &opErr):
+		return true
+	case errors.As(err, &netErr):
+		return netErr.Timeout()
+	}
+	return false
+}
+
+// SetRetryPolicy sets the retry policy of the client; nil disables retries.
+// It must be called before the client is used.
+func (a *Client) SetRetryPolicy(p *RetryPolicy) {
+	a.retry = p
+}
+
+// post sends the call, retrying it according to the client's retry policy,
+// and decodes the JSON response into v. In dry-run mode it only estimates its cost, unless it is free.
+// With a budget, the call waits for or fails without budget and its cost is recorded.
+func (a *Client) post(ctx context.Context, c *call, v interface{}) error {
+	estimate := 0
+	if a.dryRun || a.budget != nil {
+		estimate = a.api.cost(c.action, c.options)
+	}
+	// Free calls, such as KeyInfo, are made even in dry-run mode or when the budget is spent.
+	if estimate == 0 {
+		return a.sendWithRetry(ctx, c, v)
+	}
+	if a.dryRun {
+		return &DryRunError{Endpoint: c.action, Flavor: c.flavor, Transactions: estimate}
+	}
+	day, err := a.budget.acquire(ctx, estimate)
+	if err != nil {
+		return err
+	}
+	err = a.sendWithRetry(ctx, c, v)
+	if settleErr := a.budget.settle(day, estimate, c.transactions, err); settleErr != nil {
+		a.logf("alchemyapi: %s: recording transactions: %v", c.path, settleErr)
+	}
+	return err
+}
+
+// sendWithRetry sends the call, retrying it according to the client's retry policy.
+func (a *Client) sendWithRetry(ctx context.Context, c *call, v interface{}) error {
+	p := a.retry
+	if p == nil || p.MaxAttempts < 2 {
+		return a.send(ctx, c, v)
+	}
+	var body []byte
+	if c.body != nil {
+		b, err := ioutil.ReadAll(c.body)
+		if err != nil {
+			return err
+		}
+		body = b
+	}
+	retryOn := p.RetryOn
+	if retryOn == nil {
+		retryOn = Retryable
+	}
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+	for attempt := 1; ; attempt++ {
+		if body != nil {
+			c.body = bytes.NewReader(body)
+		}
+		err := a.send(ctx, c, v)
+		if err == nil || attempt >= p.MaxAttempts || !retryOn(err) {
+			return err
+		}
+		wait := p.wait(backoff)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return err
+		}
+		a.logf("alchemyapi: %s: attempt %d failed, retrying in %v: %v", c.path, attempt, wait, err)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt+1, err, wait)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff = p.next(backoff)
+		// Drop whatever the failed attempt decoded before decoding the next response.
+		reset(v)
+	}
+}
+
+// wait returns backoff with the policy's jitter applied.
+func (p *RetryPolicy) wait(backoff time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return backoff
+	}
+	jitter := p.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	return time.Duration(float64(backoff) * (1 + jitter*(2*rand.Float64()-1)))
+}
+
+// next returns the backoff following backoff.
+func (p *RetryPolicy) next(backoff time.Duration) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+	backoff = time.Duration(float64(backoff) * multiplier)
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// reset sets the value v points to to its zero value.
+func reset(v interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+}
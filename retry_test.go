@@ -0,0 +1,150 @@
+package alchemyapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	assert := NewAssert(t)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		switch r.FormValue("text") {
+		case "flaky":
+			if n < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "retrieve":
+			if n < 2 {
+				w.Write([]byte(`{"status":"ERROR","statusInfo":"cannot-retrieve:http-503"}`))
+				return
+			}
+		case "busy":
+			if n < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"status":"ERROR","statusInfo":"service-unavailable"}`))
+				return
+			}
+		case "bad-key":
+			w.Write([]byte(`{"status":"ERROR","statusInfo":"invalid-api-key"}`))
+			return
+		case "down":
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"status":"OK","language":"english"}`))
+	}))
+	defer server.Close()
+	a := New("key", server.URL, server.Client())
+	var retries []int
+	a.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Jitter: 0.5,
+		OnRetry: func(attempt int, err error, wait time.Duration) { retries = append(retries, attempt) }})
+
+	response, err := a.GetLanguage("text", "flaky")
+	assert.Equal(nil, err)
+	assert.Equal("english", response.Language)
+	assert.Equal([]int{2, 3}, retries)
+
+	atomic.StoreInt32(&calls, 0)
+	response, err = a.GetLanguage("text", "retrieve")
+	assert.Equal(nil, err)
+	assert.Equal("", response.StatusInfo)
+
+	atomic.StoreInt32(&calls, 0)
+	response, err = a.GetLanguage("text", "busy")
+	assert.Equal(nil, err)
+	assert.Equal(int32(3), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = a.Language("text", "bad-key")
+	assert.Equal(true, errors.Is(err, ErrInvalidAPIKey))
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = a.Language("text", "down")
+	var respErr *ResponseError
+	assert.Equal(true, errors.As(err, &respErr))
+	assert.Equal(http.StatusBadGateway, respErr.StatusCode)
+	assert.Equal(int32(3), atomic.LoadInt32(&calls))
+
+	// The wait would pass the deadline, so the first error is returned right away.
+	a.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour})
+	atomic.StoreInt32(&calls, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = a.LanguageContext(ctx, "text", "down")
+	assert.Equal(true, errors.As(err, &respErr))
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+
+	// Raw image bodies are replayed on every attempt.
+	a.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	var bodies []string
+	image := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"status":"OK","imageKeywords":[]}`))
+	}))
+	defer image.Close()
+	a = New("key", image.URL, image.Client())
+	a.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	_, err = a.GetImageTagsFromReader(strings.NewReader("\x89PNG"))
+	assert.Equal(nil, err)
+	assert.Equal([]string{"\x89PNG", "\x89PNG"}, bodies)
+}
+
+func TestRetryable(t *testing.T) {
+	assert := NewAssert(t)
+	assert.Equal(false, Retryable(nil))
+	assert.Equal(false, Retryable(context.DeadlineExceeded))
+	assert.Equal(false, Retryable(&APIError{Kind: ErrUnsupportedTextLanguage}))
+	assert.Equal(false, Retryable(&APIError{Kind: ErrCannotRetrieve, SubCode: 404}))
+	assert.Equal(true, Retryable(&APIError{Kind: ErrCannotRetrieve, SubCode: 502}))
+	assert.Equal(true, Retryable(&ResponseError{StatusCode: 503}))
+	assert.Equal(true, Retryable(&APIError{Kind: "service-unavailable", StatusCode: 503}))
+	assert.Equal(true, Retryable(&APIError{Kind: ErrCannotLocate, StatusCode: 429}))
+	assert.Equal(false, Retryable(&APIError{Kind: ErrInvalidAPIKey, StatusCode: 503}))
+	assert.Equal(false, Retryable(&APIError{Kind: ErrCannotLocate, StatusCode: 200}))
+
+	assert.Equal(true, Retryable(&url.Error{Op: "Post", Err: io.ErrUnexpectedEOF}))
+	assert.Equal(true, Retryable(&url.Error{Op: "Post", Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}}))
+	assert.Equal(true, Retryable(&url.Error{Op: "Post", Err: &net.DNSError{IsTimeout: true}}))
+	assert.Equal(false, Retryable(&url.Error{Op: "Post", Err: &net.DNSError{IsNotFound: true}}))
+	assert.Equal(false, Retryable(errors.New("unexpected")))
+
+	// Errors of the http.Client itself.
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	_, err := New("key", closed.URL, nil).Language("text", "Bob")
+	assert.Equal(true, Retryable(err))
+	_, err = New("key", "ftp://example.com", nil).Language("text", "Bob")
+	assert.NotNil(err)
+	assert.Equal(false, Retryable(err))
+	_, err = New("key", "http://bad host", nil).Language("text", "Bob")
+	assert.NotNil(err)
+	assert.Equal(false, Retryable(err))
+	tlsServer := httptest.NewUnstartedServer(http.NotFoundHandler())
+	tlsServer.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+	_, err = New("key", tlsServer.URL, &http.Client{}).Language("text", "Bob")
+	assert.NotNil(err)
+	assert.Equal(false, Retryable(err))
+}
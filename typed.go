@@ -0,0 +1,47 @@
+package alchemyapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+)
+
+// ErrNoImage is returned by GetExtractedImageTags when no image was found on the page.
+var ErrNoImage = errors.New("no image found")
+
+// The Get* methods, generated in endpoints_gen.go, take the same arguments as their untyped counterparts
+// but decode the response into the endpoint's response struct.
+// On an API error the partially decoded response is returned along with the error.
+
+// GetImageTagsFromReader tags the image read from image, which is posted as is
+// to the image flavor of the image tagging call.
+func (a *Client) GetImageTagsFromReader(image io.Reader, options ...url.Values) (*ImageTagsResponse, error) {
+	return a.GetImageTagsFromReaderContext(context.Background(), image, options...)
+}
+
+// GetImageTagsFromReaderContext is like GetImageTagsFromReader but uses ctx for the request.
+func (a *Client) GetImageTagsFromReaderContext(ctx context.Context, image io.Reader, options ...url.Values) (*ImageTagsResponse, error) {
+	v := &ImageTagsResponse{}
+	return v, a.analyzeImage(ctx, v, "image_tag", image, options...)
+}
+
+// GetExtractedImageTags extracts the main image of the page like GetImageExtract
+// and then tags the extracted image by its URL. The options apply to the extraction only.
+// If the page has no image, the extraction response is returned with ErrNoImage.
+func (a *Client) GetExtractedImageTags(flavor string, data string, options ...url.Values) (*ImageExtractResponse, *ImageTagsResponse, error) {
+	return a.GetExtractedImageTagsContext(context.Background(), flavor, data, options...)
+}
+
+// GetExtractedImageTagsContext is like GetExtractedImageTags but uses ctx for the request.
+func (a *Client) GetExtractedImageTagsContext(ctx context.Context, flavor string, data string, options ...url.Values) (*ImageExtractResponse, *ImageTagsResponse, error) {
+	image, err := a.GetImageExtractContext(ctx, flavor, data, options...)
+	if err != nil {
+		return image, nil, err
+	}
+	if image.Image == "" {
+		return image, nil, ErrNoImage
+	}
+	tags, err := a.GetImageTagsContext(ctx, "url", image.Image)
+	return image, tags, err
+}